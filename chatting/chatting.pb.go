@@ -11,6 +11,7 @@ package gen
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -23,6 +24,376 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Role int32
+
+const (
+	Role_ROLE_UNSPECIFIED Role = 0
+	Role_ROLE_MEMBER      Role = 1
+	Role_ROLE_MODERATOR   Role = 2
+	Role_ROLE_OWNER       Role = 3
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "ROLE_UNSPECIFIED",
+		1: "ROLE_MEMBER",
+		2: "ROLE_MODERATOR",
+		3: "ROLE_OWNER",
+	}
+	Role_value = map[string]int32{
+		"ROLE_UNSPECIFIED": 0,
+		"ROLE_MEMBER":      1,
+		"ROLE_MODERATOR":   2,
+		"ROLE_OWNER":       3,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_chatting_proto_enumTypes[0].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_chatting_proto_enumTypes[0]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{0}
+}
+
+type Visibility int32
+
+const (
+	Visibility_VISIBILITY_PUBLIC Visibility = 0
+	// private rooms are only listed for and enterable by their members
+	Visibility_VISIBILITY_PRIVATE Visibility = 1
+)
+
+// Enum value maps for Visibility.
+var (
+	Visibility_name = map[int32]string{
+		0: "VISIBILITY_PUBLIC",
+		1: "VISIBILITY_PRIVATE",
+	}
+	Visibility_value = map[string]int32{
+		"VISIBILITY_PUBLIC":  0,
+		"VISIBILITY_PRIVATE": 1,
+	}
+)
+
+func (x Visibility) Enum() *Visibility {
+	p := new(Visibility)
+	*p = x
+	return p
+}
+
+func (x Visibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Visibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_chatting_proto_enumTypes[1].Descriptor()
+}
+
+func (Visibility) Type() protoreflect.EnumType {
+	return &file_chatting_proto_enumTypes[1]
+}
+
+func (x Visibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Visibility.Descriptor instead.
+func (Visibility) EnumDescriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{1}
+}
+
+type MessageKind int32
+
+const (
+	MessageKind_MESSAGE_KIND_CHAT MessageKind = 0
+	// JOIN and LEAVE are published by the server when userId enters or
+	// exits the room.
+	MessageKind_MESSAGE_KIND_JOIN  MessageKind = 1
+	MessageKind_MESSAGE_KIND_LEAVE MessageKind = 2
+	// NOTICE is sent by the server to a single user and is not part of the
+	// room sequence.
+	MessageKind_MESSAGE_KIND_NOTICE MessageKind = 3
+	// SYSTEM announces a change of the room itself, such as its removal.
+	MessageKind_MESSAGE_KIND_SYSTEM MessageKind = 4
+)
+
+// Enum value maps for MessageKind.
+var (
+	MessageKind_name = map[int32]string{
+		0: "MESSAGE_KIND_CHAT",
+		1: "MESSAGE_KIND_JOIN",
+		2: "MESSAGE_KIND_LEAVE",
+		3: "MESSAGE_KIND_NOTICE",
+		4: "MESSAGE_KIND_SYSTEM",
+	}
+	MessageKind_value = map[string]int32{
+		"MESSAGE_KIND_CHAT":   0,
+		"MESSAGE_KIND_JOIN":   1,
+		"MESSAGE_KIND_LEAVE":  2,
+		"MESSAGE_KIND_NOTICE": 3,
+		"MESSAGE_KIND_SYSTEM": 4,
+	}
+)
+
+func (x MessageKind) Enum() *MessageKind {
+	p := new(MessageKind)
+	*p = x
+	return p
+}
+
+func (x MessageKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MessageKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_chatting_proto_enumTypes[2].Descriptor()
+}
+
+func (MessageKind) Type() protoreflect.EnumType {
+	return &file_chatting_proto_enumTypes[2]
+}
+
+func (x MessageKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MessageKind.Descriptor instead.
+func (MessageKind) EnumDescriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{2}
+}
+
+// Presence tells whether a user is around. Users are online while they have
+// an open stream or keep sending heartbeats, unless they set themselves away.
+type Presence int32
+
+const (
+	Presence_PRESENCE_OFFLINE Presence = 0
+	Presence_PRESENCE_ONLINE  Presence = 1
+	Presence_PRESENCE_AWAY    Presence = 2
+)
+
+// Enum value maps for Presence.
+var (
+	Presence_name = map[int32]string{
+		0: "PRESENCE_OFFLINE",
+		1: "PRESENCE_ONLINE",
+		2: "PRESENCE_AWAY",
+	}
+	Presence_value = map[string]int32{
+		"PRESENCE_OFFLINE": 0,
+		"PRESENCE_ONLINE":  1,
+		"PRESENCE_AWAY":    2,
+	}
+)
+
+func (x Presence) Enum() *Presence {
+	p := new(Presence)
+	*p = x
+	return p
+}
+
+func (x Presence) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Presence) Descriptor() protoreflect.EnumDescriptor {
+	return file_chatting_proto_enumTypes[3].Descriptor()
+}
+
+func (Presence) Type() protoreflect.EnumType {
+	return &file_chatting_proto_enumTypes[3]
+}
+
+func (x Presence) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Presence.Descriptor instead.
+func (Presence) EnumDescriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{3}
+}
+
+type RoomSort int32
+
+const (
+	RoomSort_ROOM_SORT_NAME         RoomSort = 0
+	RoomSort_ROOM_SORT_CREATED      RoomSort = 1
+	RoomSort_ROOM_SORT_MEMBER_COUNT RoomSort = 2
+	RoomSort_ROOM_SORT_ACTIVITY     RoomSort = 3
+)
+
+// Enum value maps for RoomSort.
+var (
+	RoomSort_name = map[int32]string{
+		0: "ROOM_SORT_NAME",
+		1: "ROOM_SORT_CREATED",
+		2: "ROOM_SORT_MEMBER_COUNT",
+		3: "ROOM_SORT_ACTIVITY",
+	}
+	RoomSort_value = map[string]int32{
+		"ROOM_SORT_NAME":         0,
+		"ROOM_SORT_CREATED":      1,
+		"ROOM_SORT_MEMBER_COUNT": 2,
+		"ROOM_SORT_ACTIVITY":     3,
+	}
+)
+
+func (x RoomSort) Enum() *RoomSort {
+	p := new(RoomSort)
+	*p = x
+	return p
+}
+
+func (x RoomSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RoomSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_chatting_proto_enumTypes[4].Descriptor()
+}
+
+func (RoomSort) Type() protoreflect.EnumType {
+	return &file_chatting_proto_enumTypes[4]
+}
+
+func (x RoomSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RoomSort.Descriptor instead.
+func (RoomSort) EnumDescriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{4}
+}
+
+type RoomEventType int32
+
+const (
+	// SNAPSHOT is sent first and carries every room visible to the caller.
+	RoomEventType_ROOM_EVENT_SNAPSHOT RoomEventType = 0
+	RoomEventType_ROOM_EVENT_CREATED  RoomEventType = 1
+	// UPDATED is sent when a room is renamed or its settings change.
+	RoomEventType_ROOM_EVENT_UPDATED RoomEventType = 2
+	// REMOVED is also sent when the caller may no longer see the room, and
+	// then carries only its ids.
+	RoomEventType_ROOM_EVENT_REMOVED              RoomEventType = 3
+	RoomEventType_ROOM_EVENT_MEMBER_COUNT_CHANGED RoomEventType = 4
+)
+
+// Enum value maps for RoomEventType.
+var (
+	RoomEventType_name = map[int32]string{
+		0: "ROOM_EVENT_SNAPSHOT",
+		1: "ROOM_EVENT_CREATED",
+		2: "ROOM_EVENT_UPDATED",
+		3: "ROOM_EVENT_REMOVED",
+		4: "ROOM_EVENT_MEMBER_COUNT_CHANGED",
+	}
+	RoomEventType_value = map[string]int32{
+		"ROOM_EVENT_SNAPSHOT":             0,
+		"ROOM_EVENT_CREATED":              1,
+		"ROOM_EVENT_UPDATED":              2,
+		"ROOM_EVENT_REMOVED":              3,
+		"ROOM_EVENT_MEMBER_COUNT_CHANGED": 4,
+	}
+)
+
+func (x RoomEventType) Enum() *RoomEventType {
+	p := new(RoomEventType)
+	*p = x
+	return p
+}
+
+func (x RoomEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RoomEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_chatting_proto_enumTypes[5].Descriptor()
+}
+
+func (RoomEventType) Type() protoreflect.EnumType {
+	return &file_chatting_proto_enumTypes[5]
+}
+
+func (x RoomEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RoomEventType.Descriptor instead.
+func (RoomEventType) EnumDescriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{5}
+}
+
+type SessionAction int32
+
+const (
+	SessionAction_SESSION_ACTION_SEND  SessionAction = 0
+	SessionAction_SESSION_ACTION_JOIN  SessionAction = 1
+	SessionAction_SESSION_ACTION_LEAVE SessionAction = 2
+)
+
+// Enum value maps for SessionAction.
+var (
+	SessionAction_name = map[int32]string{
+		0: "SESSION_ACTION_SEND",
+		1: "SESSION_ACTION_JOIN",
+		2: "SESSION_ACTION_LEAVE",
+	}
+	SessionAction_value = map[string]int32{
+		"SESSION_ACTION_SEND":  0,
+		"SESSION_ACTION_JOIN":  1,
+		"SESSION_ACTION_LEAVE": 2,
+	}
+)
+
+func (x SessionAction) Enum() *SessionAction {
+	p := new(SessionAction)
+	*p = x
+	return p
+}
+
+func (x SessionAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SessionAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_chatting_proto_enumTypes[6].Descriptor()
+}
+
+func (SessionAction) Type() protoreflect.EnumType {
+	return &file_chatting_proto_enumTypes[6]
+}
+
+func (x SessionAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SessionAction.Descriptor instead.
+func (SessionAction) EnumDescriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{6}
+}
+
 type Empty struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -60,10 +431,17 @@ func (*Empty) Descriptor() ([]byte, []int) {
 }
 
 type User struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// displayName is the name to show for the user; it is the username
+	// unless the user picked another one.
+	DisplayName string `protobuf:"bytes,3,opt,name=displayName,proto3" json:"displayName,omitempty"`
+	StatusText  string `protobuf:"bytes,4,opt,name=statusText,proto3" json:"statusText,omitempty"`
+	// avatarAttachment refers to the avatar image, such as its URL.
+	AvatarAttachment string `protobuf:"bytes,5,opt,name=avatarAttachment,proto3" json:"avatarAttachment,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *User) Reset() {
@@ -96,35 +474,66 @@ func (*User) Descriptor() ([]byte, []int) {
 	return file_chatting_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *User) GetUserId() int32 {
+func (x *User) GetUserId() int64 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-type Room struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomId        int32                  `protobuf:"varint,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
-	RoomName      string                 `protobuf:"bytes,2,opt,name=roomName,proto3" json:"roomName,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
 }
 
-func (x *Room) Reset() {
-	*x = Room{}
+func (x *User) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *User) GetStatusText() string {
+	if x != nil {
+		return x.StatusText
+	}
+	return ""
+}
+
+func (x *User) GetAvatarAttachment() string {
+	if x != nil {
+		return x.AvatarAttachment
+	}
+	return ""
+}
+
+// SetProfileRequest changes the fields that are set; an empty string clears
+// a field.
+type SetProfileRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	DisplayName      *string                `protobuf:"bytes,1,opt,name=displayName,proto3,oneof" json:"displayName,omitempty"`
+	StatusText       *string                `protobuf:"bytes,2,opt,name=statusText,proto3,oneof" json:"statusText,omitempty"`
+	AvatarAttachment *string                `protobuf:"bytes,3,opt,name=avatarAttachment,proto3,oneof" json:"avatarAttachment,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SetProfileRequest) Reset() {
+	*x = SetProfileRequest{}
 	mi := &file_chatting_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Room) String() string {
+func (x *SetProfileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Room) ProtoMessage() {}
+func (*SetProfileRequest) ProtoMessage() {}
 
-func (x *Room) ProtoReflect() protoreflect.Message {
+func (x *SetProfileRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chatting_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -136,46 +545,55 @@ func (x *Room) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Room.ProtoReflect.Descriptor instead.
-func (*Room) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetProfileRequest.ProtoReflect.Descriptor instead.
+func (*SetProfileRequest) Descriptor() ([]byte, []int) {
 	return file_chatting_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Room) GetRoomId() int32 {
-	if x != nil {
-		return x.RoomId
+func (x *SetProfileRequest) GetDisplayName() string {
+	if x != nil && x.DisplayName != nil {
+		return *x.DisplayName
 	}
-	return 0
+	return ""
 }
 
-func (x *Room) GetRoomName() string {
-	if x != nil {
-		return x.RoomName
+func (x *SetProfileRequest) GetStatusText() string {
+	if x != nil && x.StatusText != nil {
+		return *x.StatusText
 	}
 	return ""
 }
 
-type CreateRoomRequest struct {
+func (x *SetProfileRequest) GetAvatarAttachment() string {
+	if x != nil && x.AvatarAttachment != nil {
+		return *x.AvatarAttachment
+	}
+	return ""
+}
+
+// GetUserRequest looks the user up by userId, or by username when userId is 0.
+type GetUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomName      string                 `protobuf:"bytes,1,opt,name=roomName,proto3" json:"roomName,omitempty"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateRoomRequest) Reset() {
-	*x = CreateRoomRequest{}
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
 	mi := &file_chatting_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateRoomRequest) String() string {
+func (x *GetUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateRoomRequest) ProtoMessage() {}
+func (*GetUserRequest) ProtoMessage() {}
 
-func (x *CreateRoomRequest) ProtoReflect() protoreflect.Message {
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chatting_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -187,39 +605,48 @@ func (x *CreateRoomRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateRoomRequest.ProtoReflect.Descriptor instead.
-func (*CreateRoomRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
 	return file_chatting_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *CreateRoomRequest) GetRoomName() string {
+func (x *GetUserRequest) GetUserId() int64 {
 	if x != nil {
-		return x.RoomName
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetUserRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
 	}
 	return ""
 }
 
-type RemoveRoomRequest struct {
+// BatchGetUsersRequest looks up to 100 users up. Unknown users are left out
+// of the response.
+type BatchGetUsersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomId        int32                  `protobuf:"varint,1,opt,name=RoomId,proto3" json:"RoomId,omitempty"`
+	UserIds       []int64                `protobuf:"varint,1,rep,packed,name=userIds,proto3" json:"userIds,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveRoomRequest) Reset() {
-	*x = RemoveRoomRequest{}
+func (x *BatchGetUsersRequest) Reset() {
+	*x = BatchGetUsersRequest{}
 	mi := &file_chatting_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveRoomRequest) String() string {
+func (x *BatchGetUsersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveRoomRequest) ProtoMessage() {}
+func (*BatchGetUsersRequest) ProtoMessage() {}
 
-func (x *RemoveRoomRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchGetUsersRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_chatting_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -231,39 +658,39 @@ func (x *RemoveRoomRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveRoomRequest.ProtoReflect.Descriptor instead.
-func (*RemoveRoomRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGetUsersRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetUsersRequest) Descriptor() ([]byte, []int) {
 	return file_chatting_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *RemoveRoomRequest) GetRoomId() int32 {
+func (x *BatchGetUsersRequest) GetUserIds() []int64 {
 	if x != nil {
-		return x.RoomId
+		return x.UserIds
 	}
-	return 0
+	return nil
 }
 
-type RoomRequest struct {
+type BatchGetUsersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomId        int32                  `protobuf:"varint,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RoomRequest) Reset() {
-	*x = RoomRequest{}
+func (x *BatchGetUsersResponse) Reset() {
+	*x = BatchGetUsersResponse{}
 	mi := &file_chatting_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RoomRequest) String() string {
+func (x *BatchGetUsersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoomRequest) ProtoMessage() {}
+func (*BatchGetUsersResponse) ProtoMessage() {}
 
-func (x *RoomRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchGetUsersResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_chatting_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -275,39 +702,40 @@ func (x *RoomRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoomRequest.ProtoReflect.Descriptor instead.
-func (*RoomRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGetUsersResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetUsersResponse) Descriptor() ([]byte, []int) {
 	return file_chatting_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *RoomRequest) GetRoomId() int32 {
+func (x *BatchGetUsersResponse) GetUsers() []*User {
 	if x != nil {
-		return x.RoomId
+		return x.Users
 	}
-	return 0
+	return nil
 }
 
-type Message struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Msg           string                 `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// HeartbeatResponse tells when to send the next heartbeat.
+type HeartbeatResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IntervalSeconds int32                  `protobuf:"varint,1,opt,name=intervalSeconds,proto3" json:"intervalSeconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *Message) Reset() {
-	*x = Message{}
+func (x *HeartbeatResponse) Reset() {
+	*x = HeartbeatResponse{}
 	mi := &file_chatting_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Message) String() string {
+func (x *HeartbeatResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Message) ProtoMessage() {}
+func (*HeartbeatResponse) ProtoMessage() {}
 
-func (x *Message) ProtoReflect() protoreflect.Message {
+func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_chatting_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -319,48 +747,2756 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Message.ProtoReflect.Descriptor instead.
-func (*Message) Descriptor() ([]byte, []int) {
+// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
 	return file_chatting_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *Message) GetMsg() string {
+func (x *HeartbeatResponse) GetIntervalSeconds() int32 {
 	if x != nil {
-		return x.Msg
+		return x.IntervalSeconds
 	}
-	return ""
+	return 0
 }
 
-var File_chatting_proto protoreflect.FileDescriptor
+// SetPresenceRequest sets the caller away, or back, until changed again.
+type SetPresenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Away          bool                   `protobuf:"varint,1,opt,name=away,proto3" json:"away,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_chatting_proto_rawDesc = "" +
+func (x *SetPresenceRequest) Reset() {
+	*x = SetPresenceRequest{}
+	mi := &file_chatting_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPresenceRequest) ProtoMessage() {}
+
+func (x *SetPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPresenceRequest.ProtoReflect.Descriptor instead.
+func (*SetPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SetPresenceRequest) GetAway() bool {
+	if x != nil {
+		return x.Away
+	}
+	return false
+}
+
+// WatchPresenceRequest watches up to 100 users. The stream starts with the
+// presence of every one of them, followed by their changes.
+type WatchPresenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []int64                `protobuf:"varint,1,rep,packed,name=userIds,proto3" json:"userIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPresenceRequest) Reset() {
+	*x = WatchPresenceRequest{}
+	mi := &file_chatting_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPresenceRequest) ProtoMessage() {}
+
+func (x *WatchPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPresenceRequest.ProtoReflect.Descriptor instead.
+func (*WatchPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WatchPresenceRequest) GetUserIds() []int64 {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type PresenceEvent struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Presence Presence               `protobuf:"varint,2,opt,name=presence,proto3,enum=chatting.Presence" json:"presence,omitempty"`
+	// lastSeen is when the user was last online or away; unset for users
+	// who never were.
+	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PresenceEvent) Reset() {
+	*x = PresenceEvent{}
+	mi := &file_chatting_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PresenceEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresenceEvent) ProtoMessage() {}
+
+func (x *PresenceEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresenceEvent.ProtoReflect.Descriptor instead.
+func (*PresenceEvent) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PresenceEvent) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PresenceEvent) GetPresence() Presence {
+	if x != nil {
+		return x.Presence
+	}
+	return Presence_PRESENCE_OFFLINE
+}
+
+func (x *PresenceEvent) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+// RegisterRequest creates an account. Usernames are 3 to 32 letters, digits,
+// '_', '-' or '.' and unique ignoring case; passwords have at least 8
+// characters.
+type RegisterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	mi := &file_chatting_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRequest) ProtoMessage() {}
+
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RegisterRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type LoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_chatting_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LoginRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+// LoginResponse carries the session token that authenticates the following
+// calls through the "authorization: Bearer <token>" metadata.
+type LoginResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	User      *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Token     string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	// refreshToken gets a new token from RefreshSession before token expires.
+	// It can be used once; RefreshSession returns the next one.
+	RefreshToken string `protobuf:"bytes,4,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
+	SessionId    int64  `protobuf:"varint,5,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	// sessionExpiresAt is when the session ends unless it is refreshed.
+	SessionExpiresAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=sessionExpiresAt,proto3" json:"sessionExpiresAt,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_chatting_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResponse) ProtoMessage() {}
+
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LoginResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetSessionId() int64 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+func (x *LoginResponse) GetSessionExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SessionExpiresAt
+	}
+	return nil
+}
+
+type RefreshSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshSessionRequest) Reset() {
+	*x = RefreshSessionRequest{}
+	mi := &file_chatting_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshSessionRequest) ProtoMessage() {}
+
+func (x *RefreshSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshSessionRequest.ProtoReflect.Descriptor instead.
+func (*RefreshSessionRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RefreshSessionRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+// DeviceSession is one login of the user, usually one device.
+type DeviceSession struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId int64                  `protobuf:"varint,1,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	// device is the user agent the session logged in with
+	Device      string                 `protobuf:"bytes,2,opt,name=device,proto3" json:"device,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	RefreshedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=refreshedAt,proto3" json:"refreshedAt,omitempty"`
+	ExpiresAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	// current is set for the session making the call
+	Current       bool `protobuf:"varint,6,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceSession) Reset() {
+	*x = DeviceSession{}
+	mi := &file_chatting_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceSession) ProtoMessage() {}
+
+func (x *DeviceSession) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceSession.ProtoReflect.Descriptor instead.
+func (*DeviceSession) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeviceSession) GetSessionId() int64 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+func (x *DeviceSession) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *DeviceSession) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DeviceSession) GetRefreshedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefreshedAt
+	}
+	return nil
+}
+
+func (x *DeviceSession) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *DeviceSession) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*DeviceSession       `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_chatting_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*DeviceSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     int64                  `protobuf:"varint,1,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_chatting_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RevokeSessionRequest) GetSessionId() int64 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+// Workspace owns a set of users and rooms. Calls pick their workspace with
+// the workspace_id metadata; workspace 0 is the default one every user is in.
+type Workspace struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId int64                  `protobuf:"varint,1,opt,name=workspaceId,proto3" json:"workspaceId,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedBy   int64                  `protobuf:"varint,3,opt,name=createdBy,proto3" json:"createdBy,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	MemberCount int32                  `protobuf:"varint,5,opt,name=memberCount,proto3" json:"memberCount,omitempty"`
+	// admin tells whether the caller administers the workspace.
+	Admin         bool `protobuf:"varint,6,opt,name=admin,proto3" json:"admin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Workspace) Reset() {
+	*x = Workspace{}
+	mi := &file_chatting_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Workspace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Workspace) ProtoMessage() {}
+
+func (x *Workspace) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Workspace.ProtoReflect.Descriptor instead.
+func (*Workspace) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Workspace) GetWorkspaceId() int64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *Workspace) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Workspace) GetCreatedBy() int64 {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return 0
+}
+
+func (x *Workspace) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Workspace) GetMemberCount() int32 {
+	if x != nil {
+		return x.MemberCount
+	}
+	return 0
+}
+
+func (x *Workspace) GetAdmin() bool {
+	if x != nil {
+		return x.Admin
+	}
+	return false
+}
+
+type CreateWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWorkspaceRequest) Reset() {
+	*x = CreateWorkspaceRequest{}
+	mi := &file_chatting_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorkspaceRequest) ProtoMessage() {}
+
+func (x *CreateWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreateWorkspaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListWorkspacesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workspaces    []*Workspace           `protobuf:"bytes,1,rep,name=workspaces,proto3" json:"workspaces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspacesResponse) Reset() {
+	*x = ListWorkspacesResponse{}
+	mi := &file_chatting_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspacesResponse) ProtoMessage() {}
+
+func (x *ListWorkspacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspacesResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkspacesResponse) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListWorkspacesResponse) GetWorkspaces() []*Workspace {
+	if x != nil {
+		return x.Workspaces
+	}
+	return nil
+}
+
+type WorkspaceMemberRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId int64                  `protobuf:"varint,1,opt,name=workspaceId,proto3" json:"workspaceId,omitempty"`
+	UserId      int64                  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	// admin makes the added user an admin of the workspace.
+	Admin         bool `protobuf:"varint,3,opt,name=admin,proto3" json:"admin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkspaceMemberRequest) Reset() {
+	*x = WorkspaceMemberRequest{}
+	mi := &file_chatting_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceMemberRequest) ProtoMessage() {}
+
+func (x *WorkspaceMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceMemberRequest.ProtoReflect.Descriptor instead.
+func (*WorkspaceMemberRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WorkspaceMemberRequest) GetWorkspaceId() int64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *WorkspaceMemberRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *WorkspaceMemberRequest) GetAdmin() bool {
+	if x != nil {
+		return x.Admin
+	}
+	return false
+}
+
+type Room struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	RoomId      int64                  `protobuf:"varint,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	RoomName    string                 `protobuf:"bytes,2,opt,name=roomName,proto3" json:"roomName,omitempty"`
+	Topic       string                 `protobuf:"bytes,3,opt,name=topic,proto3" json:"topic,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedBy   int64                  `protobuf:"varint,5,opt,name=createdBy,proto3" json:"createdBy,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	MemberCount int32                  `protobuf:"varint,7,opt,name=memberCount,proto3" json:"memberCount,omitempty"`
+	Visibility  Visibility             `protobuf:"varint,8,opt,name=visibility,proto3,enum=chatting.Visibility" json:"visibility,omitempty"`
+	// maxMembers of 0 means unlimited.
+	MaxMembers     int32                  `protobuf:"varint,9,opt,name=maxMembers,proto3" json:"maxMembers,omitempty"`
+	LastActivityAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=lastActivityAt,proto3" json:"lastActivityAt,omitempty"`
+	// archived rooms are read-only and left out of GetChatRoom by default.
+	Archived    bool  `protobuf:"varint,11,opt,name=archived,proto3" json:"archived,omitempty"`
+	WorkspaceId int64 `protobuf:"varint,12,opt,name=workspaceId,proto3" json:"workspaceId,omitempty"`
+	// slowModeSeconds is how long members wait between two messages; 0
+	// turns slow mode off.
+	SlowModeSeconds int32 `protobuf:"varint,13,opt,name=slowModeSeconds,proto3" json:"slowModeSeconds,omitempty"`
+	// only moderators and owners may post to announcement rooms.
+	Announcement bool `protobuf:"varint,14,opt,name=announcement,proto3" json:"announcement,omitempty"`
+	// slug is the unique name of the room in its workspace, such as
+	// "team-standup".
+	Slug          string `protobuf:"bytes,15,opt,name=slug,proto3" json:"slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Room) Reset() {
+	*x = Room{}
+	mi := &file_chatting_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Room) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Room) ProtoMessage() {}
+
+func (x *Room) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Room.ProtoReflect.Descriptor instead.
+func (*Room) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Room) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *Room) GetRoomName() string {
+	if x != nil {
+		return x.RoomName
+	}
+	return ""
+}
+
+func (x *Room) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *Room) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Room) GetCreatedBy() int64 {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return 0
+}
+
+func (x *Room) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Room) GetMemberCount() int32 {
+	if x != nil {
+		return x.MemberCount
+	}
+	return 0
+}
+
+func (x *Room) GetVisibility() Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Visibility_VISIBILITY_PUBLIC
+}
+
+func (x *Room) GetMaxMembers() int32 {
+	if x != nil {
+		return x.MaxMembers
+	}
+	return 0
+}
+
+func (x *Room) GetLastActivityAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivityAt
+	}
+	return nil
+}
+
+func (x *Room) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Room) GetWorkspaceId() int64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *Room) GetSlowModeSeconds() int32 {
+	if x != nil {
+		return x.SlowModeSeconds
+	}
+	return 0
+}
+
+func (x *Room) GetAnnouncement() bool {
+	if x != nil {
+		return x.Announcement
+	}
+	return false
+}
+
+func (x *Room) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type GetChatRoomRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// nameContains keeps rooms whose name contains it, ignoring case.
+	NameContains string   `protobuf:"bytes,1,opt,name=nameContains,proto3" json:"nameContains,omitempty"`
+	Sort         RoomSort `protobuf:"varint,2,opt,name=sort,proto3,enum=chatting.RoomSort" json:"sort,omitempty"`
+	Descending   bool     `protobuf:"varint,3,opt,name=descending,proto3" json:"descending,omitempty"`
+	// pageSize defaults to 50 and is capped at 500.
+	PageSize int32 `protobuf:"varint,4,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// pageToken is the nextPageToken of the previous page.
+	PageToken       string `protobuf:"bytes,5,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	IncludeArchived bool   `protobuf:"varint,6,opt,name=includeArchived,proto3" json:"includeArchived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetChatRoomRequest) Reset() {
+	*x = GetChatRoomRequest{}
+	mi := &file_chatting_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChatRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChatRoomRequest) ProtoMessage() {}
+
+func (x *GetChatRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChatRoomRequest.ProtoReflect.Descriptor instead.
+func (*GetChatRoomRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetChatRoomRequest) GetNameContains() string {
+	if x != nil {
+		return x.NameContains
+	}
+	return ""
+}
+
+func (x *GetChatRoomRequest) GetSort() RoomSort {
+	if x != nil {
+		return x.Sort
+	}
+	return RoomSort_ROOM_SORT_NAME
+}
+
+func (x *GetChatRoomRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *GetChatRoomRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetChatRoomRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetChatRoomRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+type GetChatRoomResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Rooms []*Room                `protobuf:"bytes,1,rep,name=rooms,proto3" json:"rooms,omitempty"`
+	// nextPageToken is empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChatRoomResponse) Reset() {
+	*x = GetChatRoomResponse{}
+	mi := &file_chatting_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChatRoomResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChatRoomResponse) ProtoMessage() {}
+
+func (x *GetChatRoomResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChatRoomResponse.ProtoReflect.Descriptor instead.
+func (*GetChatRoomResponse) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetChatRoomResponse) GetRooms() []*Room {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+func (x *GetChatRoomResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type RoomEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          RoomEventType          `protobuf:"varint,1,opt,name=type,proto3,enum=chatting.RoomEventType" json:"type,omitempty"`
+	Rooms         []*Room                `protobuf:"bytes,2,rep,name=rooms,proto3" json:"rooms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomEvent) Reset() {
+	*x = RoomEvent{}
+	mi := &file_chatting_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomEvent) ProtoMessage() {}
+
+func (x *RoomEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomEvent.ProtoReflect.Descriptor instead.
+func (*RoomEvent) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RoomEvent) GetType() RoomEventType {
+	if x != nil {
+		return x.Type
+	}
+	return RoomEventType_ROOM_EVENT_SNAPSHOT
+}
+
+func (x *RoomEvent) GetRooms() []*Room {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+type CreateRoomRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RoomName        string                 `protobuf:"bytes,1,opt,name=roomName,proto3" json:"roomName,omitempty"`
+	Topic           string                 `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Visibility      Visibility             `protobuf:"varint,4,opt,name=visibility,proto3,enum=chatting.Visibility" json:"visibility,omitempty"`
+	MaxMembers      int32                  `protobuf:"varint,5,opt,name=maxMembers,proto3" json:"maxMembers,omitempty"`
+	SlowModeSeconds int32                  `protobuf:"varint,6,opt,name=slowModeSeconds,proto3" json:"slowModeSeconds,omitempty"`
+	Announcement    bool                   `protobuf:"varint,7,opt,name=announcement,proto3" json:"announcement,omitempty"`
+	// slug defaults to the slug of roomName.
+	Slug          string `protobuf:"bytes,8,opt,name=slug,proto3" json:"slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoomRequest) Reset() {
+	*x = CreateRoomRequest{}
+	mi := &file_chatting_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoomRequest) ProtoMessage() {}
+
+func (x *CreateRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoomRequest.ProtoReflect.Descriptor instead.
+func (*CreateRoomRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreateRoomRequest) GetRoomName() string {
+	if x != nil {
+		return x.RoomName
+	}
+	return ""
+}
+
+func (x *CreateRoomRequest) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *CreateRoomRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateRoomRequest) GetVisibility() Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Visibility_VISIBILITY_PUBLIC
+}
+
+func (x *CreateRoomRequest) GetMaxMembers() int32 {
+	if x != nil {
+		return x.MaxMembers
+	}
+	return 0
+}
+
+func (x *CreateRoomRequest) GetSlowModeSeconds() int32 {
+	if x != nil {
+		return x.SlowModeSeconds
+	}
+	return 0
+}
+
+func (x *CreateRoomRequest) GetAnnouncement() bool {
+	if x != nil {
+		return x.Announcement
+	}
+	return false
+}
+
+func (x *CreateRoomRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+// GetRoomByNameRequest looks a room up by its slug; "#general", "General"
+// and "general" all find the room with slug "general".
+type GetRoomByNameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoomByNameRequest) Reset() {
+	*x = GetRoomByNameRequest{}
+	mi := &file_chatting_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoomByNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoomByNameRequest) ProtoMessage() {}
+
+func (x *GetRoomByNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoomByNameRequest.ProtoReflect.Descriptor instead.
+func (*GetRoomByNameRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetRoomByNameRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// UpdateRoomRequest changes only the fields that are set. Moderators may
+// change the name, topic and description; the other fields are up to owners.
+type UpdateRoomRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RoomId          int64                  `protobuf:"varint,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	RoomName        *string                `protobuf:"bytes,2,opt,name=roomName,proto3,oneof" json:"roomName,omitempty"`
+	Topic           *string                `protobuf:"bytes,3,opt,name=topic,proto3,oneof" json:"topic,omitempty"`
+	Description     *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Visibility      *Visibility            `protobuf:"varint,5,opt,name=visibility,proto3,enum=chatting.Visibility,oneof" json:"visibility,omitempty"`
+	MaxMembers      *int32                 `protobuf:"varint,6,opt,name=maxMembers,proto3,oneof" json:"maxMembers,omitempty"`
+	SlowModeSeconds *int32                 `protobuf:"varint,7,opt,name=slowModeSeconds,proto3,oneof" json:"slowModeSeconds,omitempty"`
+	Announcement    *bool                  `protobuf:"varint,8,opt,name=announcement,proto3,oneof" json:"announcement,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateRoomRequest) Reset() {
+	*x = UpdateRoomRequest{}
+	mi := &file_chatting_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRoomRequest) ProtoMessage() {}
+
+func (x *UpdateRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRoomRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRoomRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateRoomRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *UpdateRoomRequest) GetRoomName() string {
+	if x != nil && x.RoomName != nil {
+		return *x.RoomName
+	}
+	return ""
+}
+
+func (x *UpdateRoomRequest) GetTopic() string {
+	if x != nil && x.Topic != nil {
+		return *x.Topic
+	}
+	return ""
+}
+
+func (x *UpdateRoomRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateRoomRequest) GetVisibility() Visibility {
+	if x != nil && x.Visibility != nil {
+		return *x.Visibility
+	}
+	return Visibility_VISIBILITY_PUBLIC
+}
+
+func (x *UpdateRoomRequest) GetMaxMembers() int32 {
+	if x != nil && x.MaxMembers != nil {
+		return *x.MaxMembers
+	}
+	return 0
+}
+
+func (x *UpdateRoomRequest) GetSlowModeSeconds() int32 {
+	if x != nil && x.SlowModeSeconds != nil {
+		return *x.SlowModeSeconds
+	}
+	return 0
+}
+
+func (x *UpdateRoomRequest) GetAnnouncement() bool {
+	if x != nil && x.Announcement != nil {
+		return *x.Announcement
+	}
+	return false
+}
+
+type RemoveRoomRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RoomId int64                  `protobuf:"varint,1,opt,name=RoomId,proto3" json:"RoomId,omitempty"`
+	// archive keeps the history of the room readable through GetHistory.
+	Archive       bool `protobuf:"varint,2,opt,name=archive,proto3" json:"archive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveRoomRequest) Reset() {
+	*x = RemoveRoomRequest{}
+	mi := &file_chatting_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveRoomRequest) ProtoMessage() {}
+
+func (x *RemoveRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveRoomRequest.ProtoReflect.Descriptor instead.
+func (*RemoveRoomRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RemoveRoomRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *RemoveRoomRequest) GetArchive() bool {
+	if x != nil {
+		return x.Archive
+	}
+	return false
+}
+
+type GrantRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Role          Role                   `protobuf:"varint,3,opt,name=role,proto3,enum=chatting.Role" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantRoleRequest) Reset() {
+	*x = GrantRoleRequest{}
+	mi := &file_chatting_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantRoleRequest) ProtoMessage() {}
+
+func (x *GrantRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantRoleRequest.ProtoReflect.Descriptor instead.
+func (*GrantRoleRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GrantRoleRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *GrantRoleRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GrantRoleRequest) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+// RevokeRoleRequest demotes the user back to a plain member.
+type RevokeRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeRoleRequest) Reset() {
+	*x = RevokeRoleRequest{}
+	mi := &file_chatting_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeRoleRequest) ProtoMessage() {}
+
+func (x *RevokeRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeRoleRequest.ProtoReflect.Descriptor instead.
+func (*RevokeRoleRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RevokeRoleRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *RevokeRoleRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type CreateInviteRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RoomId int64                  `protobuf:"varint,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	// ttlSeconds defaults to a day and maxUses to a single use.
+	TtlSeconds    int64 `protobuf:"varint,2,opt,name=ttlSeconds,proto3" json:"ttlSeconds,omitempty"`
+	MaxUses       int32 `protobuf:"varint,3,opt,name=maxUses,proto3" json:"maxUses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInviteRequest) Reset() {
+	*x = CreateInviteRequest{}
+	mi := &file_chatting_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInviteRequest) ProtoMessage() {}
+
+func (x *CreateInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInviteRequest.ProtoReflect.Descriptor instead.
+func (*CreateInviteRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CreateInviteRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *CreateInviteRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *CreateInviteRequest) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+type Invite struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	RoomId        int64                  `protobuf:"varint,2,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	MaxUses       int32                  `protobuf:"varint,4,opt,name=maxUses,proto3" json:"maxUses,omitempty"`
+	Uses          int32                  `protobuf:"varint,5,opt,name=uses,proto3" json:"uses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Invite) Reset() {
+	*x = Invite{}
+	mi := &file_chatting_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invite) ProtoMessage() {}
+
+func (x *Invite) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invite.ProtoReflect.Descriptor instead.
+func (*Invite) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Invite) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Invite) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *Invite) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Invite) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *Invite) GetUses() int32 {
+	if x != nil {
+		return x.Uses
+	}
+	return 0
+}
+
+type JoinByInviteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinByInviteRequest) Reset() {
+	*x = JoinByInviteRequest{}
+	mi := &file_chatting_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinByInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinByInviteRequest) ProtoMessage() {}
+
+func (x *JoinByInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinByInviteRequest.ProtoReflect.Descriptor instead.
+func (*JoinByInviteRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *JoinByInviteRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type Member struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Role          Role                   `protobuf:"varint,2,opt,name=role,proto3,enum=chatting.Role" json:"role,omitempty"`
+	DisplayName   string                 `protobuf:"bytes,3,opt,name=displayName,proto3" json:"displayName,omitempty"`
+	Presence      Presence               `protobuf:"varint,4,opt,name=presence,proto3,enum=chatting.Presence" json:"presence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Member) Reset() {
+	*x = Member{}
+	mi := &file_chatting_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Member) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Member) ProtoMessage() {}
+
+func (x *Member) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Member.ProtoReflect.Descriptor instead.
+func (*Member) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Member) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Member) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *Member) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *Member) GetPresence() Presence {
+	if x != nil {
+		return x.Presence
+	}
+	return Presence_PRESENCE_OFFLINE
+}
+
+type ListRoomMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*Member              `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoomMembersResponse) Reset() {
+	*x = ListRoomMembersResponse{}
+	mi := &file_chatting_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoomMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoomMembersResponse) ProtoMessage() {}
+
+func (x *ListRoomMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoomMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListRoomMembersResponse) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListRoomMembersResponse) GetMembers() []*Member {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type ModerationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerationRequest) Reset() {
+	*x = ModerationRequest{}
+	mi := &file_chatting_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerationRequest) ProtoMessage() {}
+
+func (x *ModerationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerationRequest.ProtoReflect.Descriptor instead.
+func (*ModerationRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ModerationRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *ModerationRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ModerationRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// MuteUserRequest mutes the user for durationSeconds; 0 lifts the mute.
+type MuteUserRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RoomId          int64                  `protobuf:"varint,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	UserId          int64                  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	DurationSeconds int64                  `protobuf:"varint,3,opt,name=durationSeconds,proto3" json:"durationSeconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MuteUserRequest) Reset() {
+	*x = MuteUserRequest{}
+	mi := &file_chatting_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuteUserRequest) ProtoMessage() {}
+
+func (x *MuteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuteUserRequest.ProtoReflect.Descriptor instead.
+func (*MuteUserRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *MuteUserRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *MuteUserRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *MuteUserRequest) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+type RoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomRequest) Reset() {
+	*x = RoomRequest{}
+	mi := &file_chatting_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomRequest) ProtoMessage() {}
+
+func (x *RoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomRequest.ProtoReflect.Descriptor instead.
+func (*RoomRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RoomRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+// WaitlistEvent reports the position of the user on the waitlist of a full
+// room. Once admitted, a slot is held for the user until the stream closes.
+type WaitlistEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	Position      int32                  `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`
+	Admitted      bool                   `protobuf:"varint,3,opt,name=admitted,proto3" json:"admitted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitlistEvent) Reset() {
+	*x = WaitlistEvent{}
+	mi := &file_chatting_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitlistEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitlistEvent) ProtoMessage() {}
+
+func (x *WaitlistEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitlistEvent.ProtoReflect.Descriptor instead.
+func (*WaitlistEvent) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *WaitlistEvent) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *WaitlistEvent) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *WaitlistEvent) GetAdmitted() bool {
+	if x != nil {
+		return x.Admitted
+	}
+	return false
+}
+
+// SessionRequest is a frame sent on a Session stream. JOIN and LEAVE enter
+// and exit roomId; SEND posts message to it.
+type SessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	Action        SessionAction          `protobuf:"varint,2,opt,name=action,proto3,enum=chatting.SessionAction" json:"action,omitempty"`
+	Message       *Message               `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionRequest) Reset() {
+	*x = SessionRequest{}
+	mi := &file_chatting_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionRequest) ProtoMessage() {}
+
+func (x *SessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionRequest.ProtoReflect.Descriptor instead.
+func (*SessionRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SessionRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *SessionRequest) GetAction() SessionAction {
+	if x != nil {
+		return x.Action
+	}
+	return SessionAction_SESSION_ACTION_SEND
+}
+
+func (x *SessionRequest) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+// SessionEvent carries a message of roomId. Failed actions are answered
+// with a NOTICE message.
+type SessionEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	Message       *Message               `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionEvent) Reset() {
+	*x = SessionEvent{}
+	mi := &file_chatting_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionEvent) ProtoMessage() {}
+
+func (x *SessionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionEvent.ProtoReflect.Descriptor instead.
+func (*SessionEvent) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SessionEvent) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *SessionEvent) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+// HistoryRequest reads the messages with fromSeq <= seq <= toSeq, from the
+// oldest one kept when fromSeq is 0 and up to the latest one when toSeq is 0.
+// Rooms in use keep only their latest 1000 messages; archived rooms keep all
+// of theirs from then on. Asking for messages no longer kept fails with
+// OUT_OF_RANGE.
+type HistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	FromSeq       int64                  `protobuf:"varint,2,opt,name=fromSeq,proto3" json:"fromSeq,omitempty"`
+	ToSeq         int64                  `protobuf:"varint,3,opt,name=toSeq,proto3" json:"toSeq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryRequest) Reset() {
+	*x = HistoryRequest{}
+	mi := &file_chatting_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryRequest) ProtoMessage() {}
+
+func (x *HistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryRequest.ProtoReflect.Descriptor instead.
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *HistoryRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *HistoryRequest) GetFromSeq() int64 {
+	if x != nil {
+		return x.FromSeq
+	}
+	return 0
+}
+
+func (x *HistoryRequest) GetToSeq() int64 {
+	if x != nil {
+		return x.ToSeq
+	}
+	return 0
+}
+
+// SearchHistoryRequest finds chat messages containing query, ignoring case,
+// oldest first.
+type SearchHistoryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RoomId int64                  `protobuf:"varint,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	Query  string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	// limit defaults to 100.
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHistoryRequest) Reset() {
+	*x = SearchHistoryRequest{}
+	mi := &file_chatting_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHistoryRequest) ProtoMessage() {}
+
+func (x *SearchHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHistoryRequest.ProtoReflect.Descriptor instead.
+func (*SearchHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SearchHistoryRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *SearchHistoryRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Message struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Msg    string                 `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+	Seq    int64                  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	UserId int64                  `protobuf:"varint,3,opt,name=userId,proto3" json:"userId,omitempty"`
+	// dedupKey is chosen by the sender; resending a message with the same key
+	// does not deliver it again.
+	DedupKey  string      `protobuf:"bytes,4,opt,name=dedupKey,proto3" json:"dedupKey,omitempty"`
+	MessageId int64       `protobuf:"varint,5,opt,name=messageId,proto3" json:"messageId,omitempty"`
+	Kind      MessageKind `protobuf:"varint,6,opt,name=kind,proto3,enum=chatting.MessageKind" json:"kind,omitempty"`
+	// displayName is the one of userId when the message was sent.
+	DisplayName   string `protobuf:"bytes,7,opt,name=displayName,proto3" json:"displayName,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Message) Reset() {
+	*x = Message{}
+	mi := &file_chatting_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message) ProtoMessage() {}
+
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_chatting_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_chatting_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *Message) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *Message) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Message) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Message) GetDedupKey() string {
+	if x != nil {
+		return x.DedupKey
+	}
+	return ""
+}
+
+func (x *Message) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *Message) GetKind() MessageKind {
+	if x != nil {
+		return x.Kind
+	}
+	return MessageKind_MESSAGE_KIND_CHAT
+}
+
+func (x *Message) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+var File_chatting_proto protoreflect.FileDescriptor
+
+const file_chatting_proto_rawDesc = "" +
 	"\n" +
-	"\x0echatting.proto\x12\bchatting\"\a\n" +
-	"\x05Empty\"\x1e\n" +
+	"\x0echatting.proto\x12\bchatting\x1a\x1fgoogle/protobuf/timestamp.proto\"\a\n" +
+	"\x05Empty\"\xa8\x01\n" +
 	"\x04User\x12\x16\n" +
-	"\x06userId\x18\x01 \x01(\x05R\x06userId\":\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12 \n" +
+	"\vdisplayName\x18\x03 \x01(\tR\vdisplayName\x12\x1e\n" +
+	"\n" +
+	"statusText\x18\x04 \x01(\tR\n" +
+	"statusText\x12*\n" +
+	"\x10avatarAttachment\x18\x05 \x01(\tR\x10avatarAttachment\"\xc4\x01\n" +
+	"\x11SetProfileRequest\x12%\n" +
+	"\vdisplayName\x18\x01 \x01(\tH\x00R\vdisplayName\x88\x01\x01\x12#\n" +
+	"\n" +
+	"statusText\x18\x02 \x01(\tH\x01R\n" +
+	"statusText\x88\x01\x01\x12/\n" +
+	"\x10avatarAttachment\x18\x03 \x01(\tH\x02R\x10avatarAttachment\x88\x01\x01B\x0e\n" +
+	"\f_displayNameB\r\n" +
+	"\v_statusTextB\x13\n" +
+	"\x11_avatarAttachment\"D\n" +
+	"\x0eGetUserRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\"0\n" +
+	"\x14BatchGetUsersRequest\x12\x18\n" +
+	"\auserIds\x18\x01 \x03(\x03R\auserIds\"=\n" +
+	"\x15BatchGetUsersResponse\x12$\n" +
+	"\x05users\x18\x01 \x03(\v2\x0e.chatting.UserR\x05users\"=\n" +
+	"\x11HeartbeatResponse\x12(\n" +
+	"\x0fintervalSeconds\x18\x01 \x01(\x05R\x0fintervalSeconds\"(\n" +
+	"\x12SetPresenceRequest\x12\x12\n" +
+	"\x04away\x18\x01 \x01(\bR\x04away\"0\n" +
+	"\x14WatchPresenceRequest\x12\x18\n" +
+	"\auserIds\x18\x01 \x03(\x03R\auserIds\"\x8f\x01\n" +
+	"\rPresenceEvent\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12.\n" +
+	"\bpresence\x18\x02 \x01(\x0e2\x12.chatting.PresenceR\bpresence\x126\n" +
+	"\blastSeen\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\"I\n" +
+	"\x0fRegisterRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"F\n" +
+	"\fLoginRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\x8d\x02\n" +
+	"\rLoginResponse\x12\"\n" +
+	"\x04user\x18\x01 \x01(\v2\x0e.chatting.UserR\x04user\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x128\n" +
+	"\texpiresAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\"\n" +
+	"\frefreshToken\x18\x04 \x01(\tR\frefreshToken\x12\x1c\n" +
+	"\tsessionId\x18\x05 \x01(\x03R\tsessionId\x12F\n" +
+	"\x10sessionExpiresAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x10sessionExpiresAt\";\n" +
+	"\x15RefreshSessionRequest\x12\"\n" +
+	"\frefreshToken\x18\x01 \x01(\tR\frefreshToken\"\x91\x02\n" +
+	"\rDeviceSession\x12\x1c\n" +
+	"\tsessionId\x18\x01 \x01(\x03R\tsessionId\x12\x16\n" +
+	"\x06device\x18\x02 \x01(\tR\x06device\x128\n" +
+	"\tcreatedAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\vrefreshedAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vrefreshedAt\x128\n" +
+	"\texpiresAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x18\n" +
+	"\acurrent\x18\x06 \x01(\bR\acurrent\"K\n" +
+	"\x14ListSessionsResponse\x123\n" +
+	"\bsessions\x18\x01 \x03(\v2\x17.chatting.DeviceSessionR\bsessions\"4\n" +
+	"\x14RevokeSessionRequest\x12\x1c\n" +
+	"\tsessionId\x18\x01 \x01(\x03R\tsessionId\"\xd1\x01\n" +
+	"\tWorkspace\x12 \n" +
+	"\vworkspaceId\x18\x01 \x01(\x03R\vworkspaceId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1c\n" +
+	"\tcreatedBy\x18\x03 \x01(\x03R\tcreatedBy\x128\n" +
+	"\tcreatedAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12 \n" +
+	"\vmemberCount\x18\x05 \x01(\x05R\vmemberCount\x12\x14\n" +
+	"\x05admin\x18\x06 \x01(\bR\x05admin\",\n" +
+	"\x16CreateWorkspaceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"M\n" +
+	"\x16ListWorkspacesResponse\x123\n" +
+	"\n" +
+	"workspaces\x18\x01 \x03(\v2\x13.chatting.WorkspaceR\n" +
+	"workspaces\"h\n" +
+	"\x16WorkspaceMemberRequest\x12 \n" +
+	"\vworkspaceId\x18\x01 \x01(\x03R\vworkspaceId\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05admin\x18\x03 \x01(\bR\x05admin\"\xa6\x04\n" +
 	"\x04Room\x12\x16\n" +
-	"\x06roomId\x18\x01 \x01(\x05R\x06roomId\x12\x1a\n" +
-	"\broomName\x18\x02 \x01(\tR\broomName\"/\n" +
+	"\x06roomId\x18\x01 \x01(\x03R\x06roomId\x12\x1a\n" +
+	"\broomName\x18\x02 \x01(\tR\broomName\x12\x14\n" +
+	"\x05topic\x18\x03 \x01(\tR\x05topic\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1c\n" +
+	"\tcreatedBy\x18\x05 \x01(\x03R\tcreatedBy\x128\n" +
+	"\tcreatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12 \n" +
+	"\vmemberCount\x18\a \x01(\x05R\vmemberCount\x124\n" +
+	"\n" +
+	"visibility\x18\b \x01(\x0e2\x14.chatting.VisibilityR\n" +
+	"visibility\x12\x1e\n" +
+	"\n" +
+	"maxMembers\x18\t \x01(\x05R\n" +
+	"maxMembers\x12B\n" +
+	"\x0elastActivityAt\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x0elastActivityAt\x12\x1a\n" +
+	"\barchived\x18\v \x01(\bR\barchived\x12 \n" +
+	"\vworkspaceId\x18\f \x01(\x03R\vworkspaceId\x12(\n" +
+	"\x0fslowModeSeconds\x18\r \x01(\x05R\x0fslowModeSeconds\x12\"\n" +
+	"\fannouncement\x18\x0e \x01(\bR\fannouncement\x12\x12\n" +
+	"\x04slug\x18\x0f \x01(\tR\x04slug\"\xe4\x01\n" +
+	"\x12GetChatRoomRequest\x12\"\n" +
+	"\fnameContains\x18\x01 \x01(\tR\fnameContains\x12&\n" +
+	"\x04sort\x18\x02 \x01(\x0e2\x12.chatting.RoomSortR\x04sort\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x03 \x01(\bR\n" +
+	"descending\x12\x1a\n" +
+	"\bpageSize\x18\x04 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\x05 \x01(\tR\tpageToken\x12(\n" +
+	"\x0fincludeArchived\x18\x06 \x01(\bR\x0fincludeArchived\"a\n" +
+	"\x13GetChatRoomResponse\x12$\n" +
+	"\x05rooms\x18\x01 \x03(\v2\x0e.chatting.RoomR\x05rooms\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\"^\n" +
+	"\tRoomEvent\x12+\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x17.chatting.RoomEventTypeR\x04type\x12$\n" +
+	"\x05rooms\x18\x02 \x03(\v2\x0e.chatting.RoomR\x05rooms\"\x9f\x02\n" +
 	"\x11CreateRoomRequest\x12\x1a\n" +
-	"\broomName\x18\x01 \x01(\tR\broomName\"+\n" +
+	"\broomName\x18\x01 \x01(\tR\broomName\x12\x14\n" +
+	"\x05topic\x18\x02 \x01(\tR\x05topic\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x124\n" +
+	"\n" +
+	"visibility\x18\x04 \x01(\x0e2\x14.chatting.VisibilityR\n" +
+	"visibility\x12\x1e\n" +
+	"\n" +
+	"maxMembers\x18\x05 \x01(\x05R\n" +
+	"maxMembers\x12(\n" +
+	"\x0fslowModeSeconds\x18\x06 \x01(\x05R\x0fslowModeSeconds\x12\"\n" +
+	"\fannouncement\x18\a \x01(\bR\fannouncement\x12\x12\n" +
+	"\x04slug\x18\b \x01(\tR\x04slug\"*\n" +
+	"\x14GetRoomByNameRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xb0\x03\n" +
+	"\x11UpdateRoomRequest\x12\x16\n" +
+	"\x06roomId\x18\x01 \x01(\x03R\x06roomId\x12\x1f\n" +
+	"\broomName\x18\x02 \x01(\tH\x00R\broomName\x88\x01\x01\x12\x19\n" +
+	"\x05topic\x18\x03 \x01(\tH\x01R\x05topic\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x02R\vdescription\x88\x01\x01\x129\n" +
+	"\n" +
+	"visibility\x18\x05 \x01(\x0e2\x14.chatting.VisibilityH\x03R\n" +
+	"visibility\x88\x01\x01\x12#\n" +
+	"\n" +
+	"maxMembers\x18\x06 \x01(\x05H\x04R\n" +
+	"maxMembers\x88\x01\x01\x12-\n" +
+	"\x0fslowModeSeconds\x18\a \x01(\x05H\x05R\x0fslowModeSeconds\x88\x01\x01\x12'\n" +
+	"\fannouncement\x18\b \x01(\bH\x06R\fannouncement\x88\x01\x01B\v\n" +
+	"\t_roomNameB\b\n" +
+	"\x06_topicB\x0e\n" +
+	"\f_descriptionB\r\n" +
+	"\v_visibilityB\r\n" +
+	"\v_maxMembersB\x12\n" +
+	"\x10_slowModeSecondsB\x0f\n" +
+	"\r_announcement\"E\n" +
 	"\x11RemoveRoomRequest\x12\x16\n" +
-	"\x06RoomId\x18\x01 \x01(\x05R\x06RoomId\"%\n" +
+	"\x06RoomId\x18\x01 \x01(\x03R\x06RoomId\x12\x18\n" +
+	"\aarchive\x18\x02 \x01(\bR\aarchive\"f\n" +
+	"\x10GrantRoleRequest\x12\x16\n" +
+	"\x06roomId\x18\x01 \x01(\x03R\x06roomId\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\x03R\x06userId\x12\"\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x0e.chatting.RoleR\x04role\"C\n" +
+	"\x11RevokeRoleRequest\x12\x16\n" +
+	"\x06roomId\x18\x01 \x01(\x03R\x06roomId\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\x03R\x06userId\"g\n" +
+	"\x13CreateInviteRequest\x12\x16\n" +
+	"\x06roomId\x18\x01 \x01(\x03R\x06roomId\x12\x1e\n" +
+	"\n" +
+	"ttlSeconds\x18\x02 \x01(\x03R\n" +
+	"ttlSeconds\x12\x18\n" +
+	"\amaxUses\x18\x03 \x01(\x05R\amaxUses\"\x9c\x01\n" +
+	"\x06Invite\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x16\n" +
+	"\x06roomId\x18\x02 \x01(\x03R\x06roomId\x128\n" +
+	"\texpiresAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x18\n" +
+	"\amaxUses\x18\x04 \x01(\x05R\amaxUses\x12\x12\n" +
+	"\x04uses\x18\x05 \x01(\x05R\x04uses\")\n" +
+	"\x13JoinByInviteRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"\x96\x01\n" +
+	"\x06Member\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\"\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x0e.chatting.RoleR\x04role\x12 \n" +
+	"\vdisplayName\x18\x03 \x01(\tR\vdisplayName\x12.\n" +
+	"\bpresence\x18\x04 \x01(\x0e2\x12.chatting.PresenceR\bpresence\"E\n" +
+	"\x17ListRoomMembersResponse\x12*\n" +
+	"\amembers\x18\x01 \x03(\v2\x10.chatting.MemberR\amembers\"[\n" +
+	"\x11ModerationRequest\x12\x16\n" +
+	"\x06roomId\x18\x01 \x01(\x03R\x06roomId\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"k\n" +
+	"\x0fMuteUserRequest\x12\x16\n" +
+	"\x06roomId\x18\x01 \x01(\x03R\x06roomId\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\x03R\x06userId\x12(\n" +
+	"\x0fdurationSeconds\x18\x03 \x01(\x03R\x0fdurationSeconds\"%\n" +
 	"\vRoomRequest\x12\x16\n" +
-	"\x06roomId\x18\x01 \x01(\x05R\x06roomId\"\x1b\n" +
+	"\x06roomId\x18\x01 \x01(\x03R\x06roomId\"_\n" +
+	"\rWaitlistEvent\x12\x16\n" +
+	"\x06roomId\x18\x01 \x01(\x03R\x06roomId\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x05R\bposition\x12\x1a\n" +
+	"\badmitted\x18\x03 \x01(\bR\badmitted\"\x86\x01\n" +
+	"\x0eSessionRequest\x12\x16\n" +
+	"\x06roomId\x18\x01 \x01(\x03R\x06roomId\x12/\n" +
+	"\x06action\x18\x02 \x01(\x0e2\x17.chatting.SessionActionR\x06action\x12+\n" +
+	"\amessage\x18\x03 \x01(\v2\x11.chatting.MessageR\amessage\"S\n" +
+	"\fSessionEvent\x12\x16\n" +
+	"\x06roomId\x18\x01 \x01(\x03R\x06roomId\x12+\n" +
+	"\amessage\x18\x02 \x01(\v2\x11.chatting.MessageR\amessage\"X\n" +
+	"\x0eHistoryRequest\x12\x16\n" +
+	"\x06roomId\x18\x01 \x01(\x03R\x06roomId\x12\x18\n" +
+	"\afromSeq\x18\x02 \x01(\x03R\afromSeq\x12\x14\n" +
+	"\x05toSeq\x18\x03 \x01(\x03R\x05toSeq\"Z\n" +
+	"\x14SearchHistoryRequest\x12\x16\n" +
+	"\x06roomId\x18\x01 \x01(\x03R\x06roomId\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xcc\x01\n" +
 	"\aMessage\x12\x10\n" +
-	"\x03msg\x18\x01 \x01(\tR\x03msg2\xaa\x03\n" +
-	"\bChatting\x12(\n" +
-	"\x05Login\x12\x0f.chatting.Empty\x1a\x0e.chatting.User\x12*\n" +
-	"\x06Logout\x12\x0f.chatting.Empty\x1a\x0f.chatting.Empty\x120\n" +
-	"\vGetChatRoom\x12\x0f.chatting.Empty\x1a\x0e.chatting.Room0\x01\x129\n" +
+	"\x03msg\x18\x01 \x01(\tR\x03msg\x12\x10\n" +
+	"\x03seq\x18\x02 \x01(\x03R\x03seq\x12\x16\n" +
+	"\x06userId\x18\x03 \x01(\x03R\x06userId\x12\x1a\n" +
+	"\bdedupKey\x18\x04 \x01(\tR\bdedupKey\x12\x1c\n" +
+	"\tmessageId\x18\x05 \x01(\x03R\tmessageId\x12)\n" +
+	"\x04kind\x18\x06 \x01(\x0e2\x15.chatting.MessageKindR\x04kind\x12 \n" +
+	"\vdisplayName\x18\a \x01(\tR\vdisplayName*Q\n" +
+	"\x04Role\x12\x14\n" +
+	"\x10ROLE_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vROLE_MEMBER\x10\x01\x12\x12\n" +
+	"\x0eROLE_MODERATOR\x10\x02\x12\x0e\n" +
+	"\n" +
+	"ROLE_OWNER\x10\x03*;\n" +
+	"\n" +
+	"Visibility\x12\x15\n" +
+	"\x11VISIBILITY_PUBLIC\x10\x00\x12\x16\n" +
+	"\x12VISIBILITY_PRIVATE\x10\x01*\x85\x01\n" +
+	"\vMessageKind\x12\x15\n" +
+	"\x11MESSAGE_KIND_CHAT\x10\x00\x12\x15\n" +
+	"\x11MESSAGE_KIND_JOIN\x10\x01\x12\x16\n" +
+	"\x12MESSAGE_KIND_LEAVE\x10\x02\x12\x17\n" +
+	"\x13MESSAGE_KIND_NOTICE\x10\x03\x12\x17\n" +
+	"\x13MESSAGE_KIND_SYSTEM\x10\x04*H\n" +
+	"\bPresence\x12\x14\n" +
+	"\x10PRESENCE_OFFLINE\x10\x00\x12\x13\n" +
+	"\x0fPRESENCE_ONLINE\x10\x01\x12\x11\n" +
+	"\rPRESENCE_AWAY\x10\x02*i\n" +
+	"\bRoomSort\x12\x12\n" +
+	"\x0eROOM_SORT_NAME\x10\x00\x12\x15\n" +
+	"\x11ROOM_SORT_CREATED\x10\x01\x12\x1a\n" +
+	"\x16ROOM_SORT_MEMBER_COUNT\x10\x02\x12\x16\n" +
+	"\x12ROOM_SORT_ACTIVITY\x10\x03*\x95\x01\n" +
+	"\rRoomEventType\x12\x17\n" +
+	"\x13ROOM_EVENT_SNAPSHOT\x10\x00\x12\x16\n" +
+	"\x12ROOM_EVENT_CREATED\x10\x01\x12\x16\n" +
+	"\x12ROOM_EVENT_UPDATED\x10\x02\x12\x16\n" +
+	"\x12ROOM_EVENT_REMOVED\x10\x03\x12#\n" +
+	"\x1fROOM_EVENT_MEMBER_COUNT_CHANGED\x10\x04*[\n" +
+	"\rSessionAction\x12\x17\n" +
+	"\x13SESSION_ACTION_SEND\x10\x00\x12\x17\n" +
+	"\x13SESSION_ACTION_JOIN\x10\x01\x12\x18\n" +
+	"\x14SESSION_ACTION_LEAVE\x10\x022\xdd\x13\n" +
+	"\bChatting\x12>\n" +
+	"\bRegister\x12\x19.chatting.RegisterRequest\x1a\x17.chatting.LoginResponse\x128\n" +
+	"\x05Login\x12\x16.chatting.LoginRequest\x1a\x17.chatting.LoginResponse\x12*\n" +
+	"\x06Logout\x12\x0f.chatting.Empty\x1a\x0f.chatting.Empty\x12J\n" +
+	"\x0eRefreshSession\x12\x1f.chatting.RefreshSessionRequest\x1a\x17.chatting.LoginResponse\x12?\n" +
+	"\fListSessions\x12\x0f.chatting.Empty\x1a\x1e.chatting.ListSessionsResponse\x12@\n" +
+	"\rRevokeSession\x12\x1e.chatting.RevokeSessionRequest\x1a\x0f.chatting.Empty\x129\n" +
+	"\n" +
+	"SetProfile\x12\x1b.chatting.SetProfileRequest\x1a\x0e.chatting.User\x123\n" +
+	"\aGetUser\x12\x18.chatting.GetUserRequest\x1a\x0e.chatting.User\x12P\n" +
+	"\rBatchGetUsers\x12\x1e.chatting.BatchGetUsersRequest\x1a\x1f.chatting.BatchGetUsersResponse\x129\n" +
+	"\tHeartbeat\x12\x0f.chatting.Empty\x1a\x1b.chatting.HeartbeatResponse\x12<\n" +
+	"\vSetPresence\x12\x1c.chatting.SetPresenceRequest\x1a\x0f.chatting.Empty\x12J\n" +
+	"\rWatchPresence\x12\x1e.chatting.WatchPresenceRequest\x1a\x17.chatting.PresenceEvent0\x01\x12H\n" +
+	"\x0fCreateWorkspace\x12 .chatting.CreateWorkspaceRequest\x1a\x13.chatting.Workspace\x12C\n" +
+	"\x0eListWorkspaces\x12\x0f.chatting.Empty\x1a .chatting.ListWorkspacesResponse\x12G\n" +
+	"\x12AddWorkspaceMember\x12 .chatting.WorkspaceMemberRequest\x1a\x0f.chatting.Empty\x12J\n" +
+	"\x15RemoveWorkspaceMember\x12 .chatting.WorkspaceMemberRequest\x1a\x0f.chatting.Empty\x12J\n" +
+	"\vGetChatRoom\x12\x1c.chatting.GetChatRoomRequest\x1a\x1d.chatting.GetChatRoomResponse\x124\n" +
+	"\n" +
+	"WatchRooms\x12\x0f.chatting.Empty\x1a\x13.chatting.RoomEvent0\x01\x12?\n" +
+	"\rGetRoomByName\x12\x1e.chatting.GetRoomByNameRequest\x1a\x0e.chatting.Room\x129\n" +
 	"\n" +
 	"CreateRoom\x12\x1b.chatting.CreateRoomRequest\x1a\x0e.chatting.Room\x12:\n" +
 	"\n" +
-	"RemoveRoom\x12\x1b.chatting.RemoveRoomRequest\x1a\x0f.chatting.Empty\x127\n" +
+	"RemoveRoom\x12\x1b.chatting.RemoveRoomRequest\x1a\x0f.chatting.Empty\x129\n" +
+	"\n" +
+	"UpdateRoom\x12\x1b.chatting.UpdateRoomRequest\x1a\x0e.chatting.Room\x124\n" +
+	"\vArchiveRoom\x12\x15.chatting.RoomRequest\x1a\x0e.chatting.Room\x126\n" +
+	"\rUnarchiveRoom\x12\x15.chatting.RoomRequest\x1a\x0e.chatting.Room\x128\n" +
+	"\tGrantRole\x12\x1a.chatting.GrantRoleRequest\x1a\x0f.chatting.Empty\x12:\n" +
+	"\n" +
+	"RevokeRole\x12\x1b.chatting.RevokeRoleRequest\x1a\x0f.chatting.Empty\x12?\n" +
+	"\fCreateInvite\x12\x1d.chatting.CreateInviteRequest\x1a\x10.chatting.Invite\x12=\n" +
+	"\fJoinByInvite\x12\x1d.chatting.JoinByInviteRequest\x1a\x0e.chatting.Room\x127\n" +
 	"\rEnterChatRoom\x12\x15.chatting.RoomRequest\x1a\x0f.chatting.Empty\x120\n" +
-	"\fExitChatRoom\x12\x0f.chatting.Empty\x1a\x0f.chatting.Empty\x124\n" +
-	"\bChatting\x12\x11.chatting.Message\x1a\x11.chatting.Message(\x010\x01B\x83\x01\n" +
+	"\fExitChatRoom\x12\x0f.chatting.Empty\x1a\x0f.chatting.Empty\x12@\n" +
+	"\fJoinWaitlist\x12\x15.chatting.RoomRequest\x1a\x17.chatting.WaitlistEvent0\x01\x12K\n" +
+	"\x0fListRoomMembers\x12\x15.chatting.RoomRequest\x1a!.chatting.ListRoomMembersResponse\x128\n" +
+	"\bKickUser\x12\x1b.chatting.ModerationRequest\x1a\x0f.chatting.Empty\x127\n" +
+	"\aBanUser\x12\x1b.chatting.ModerationRequest\x1a\x0f.chatting.Empty\x129\n" +
+	"\tUnbanUser\x12\x1b.chatting.ModerationRequest\x1a\x0f.chatting.Empty\x126\n" +
+	"\bMuteUser\x12\x19.chatting.MuteUserRequest\x1a\x0f.chatting.Empty\x124\n" +
+	"\bChatting\x12\x11.chatting.Message\x1a\x11.chatting.Message(\x010\x01\x12?\n" +
+	"\aSession\x12\x18.chatting.SessionRequest\x1a\x16.chatting.SessionEvent(\x010\x01\x12;\n" +
+	"\n" +
+	"GetHistory\x12\x18.chatting.HistoryRequest\x1a\x11.chatting.Message0\x01\x12D\n" +
+	"\rSearchHistory\x12\x1e.chatting.SearchHistoryRequest\x1a\x11.chatting.Message0\x01B\x83\x01\n" +
 	"\fcom.chattingB\rChattingProtoP\x01Z$github.com/bufbuild/buf-examples/gen\xa2\x02\x03CXX\xaa\x02\bChatting\xca\x02\bChatting\xe2\x02\x14Chatting\\GPBMetadata\xea\x02\bChattingb\x06proto3"
 
 var (
@@ -375,38 +3511,179 @@ func file_chatting_proto_rawDescGZIP() []byte {
 	return file_chatting_proto_rawDescData
 }
 
-var file_chatting_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_chatting_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_chatting_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_chatting_proto_goTypes = []any{
-	(*Empty)(nil),             // 0: chatting.Empty
-	(*User)(nil),              // 1: chatting.User
-	(*Room)(nil),              // 2: chatting.Room
-	(*CreateRoomRequest)(nil), // 3: chatting.CreateRoomRequest
-	(*RemoveRoomRequest)(nil), // 4: chatting.RemoveRoomRequest
-	(*RoomRequest)(nil),       // 5: chatting.RoomRequest
-	(*Message)(nil),           // 6: chatting.Message
+	(Role)(0),                       // 0: chatting.Role
+	(Visibility)(0),                 // 1: chatting.Visibility
+	(MessageKind)(0),                // 2: chatting.MessageKind
+	(Presence)(0),                   // 3: chatting.Presence
+	(RoomSort)(0),                   // 4: chatting.RoomSort
+	(RoomEventType)(0),              // 5: chatting.RoomEventType
+	(SessionAction)(0),              // 6: chatting.SessionAction
+	(*Empty)(nil),                   // 7: chatting.Empty
+	(*User)(nil),                    // 8: chatting.User
+	(*SetProfileRequest)(nil),       // 9: chatting.SetProfileRequest
+	(*GetUserRequest)(nil),          // 10: chatting.GetUserRequest
+	(*BatchGetUsersRequest)(nil),    // 11: chatting.BatchGetUsersRequest
+	(*BatchGetUsersResponse)(nil),   // 12: chatting.BatchGetUsersResponse
+	(*HeartbeatResponse)(nil),       // 13: chatting.HeartbeatResponse
+	(*SetPresenceRequest)(nil),      // 14: chatting.SetPresenceRequest
+	(*WatchPresenceRequest)(nil),    // 15: chatting.WatchPresenceRequest
+	(*PresenceEvent)(nil),           // 16: chatting.PresenceEvent
+	(*RegisterRequest)(nil),         // 17: chatting.RegisterRequest
+	(*LoginRequest)(nil),            // 18: chatting.LoginRequest
+	(*LoginResponse)(nil),           // 19: chatting.LoginResponse
+	(*RefreshSessionRequest)(nil),   // 20: chatting.RefreshSessionRequest
+	(*DeviceSession)(nil),           // 21: chatting.DeviceSession
+	(*ListSessionsResponse)(nil),    // 22: chatting.ListSessionsResponse
+	(*RevokeSessionRequest)(nil),    // 23: chatting.RevokeSessionRequest
+	(*Workspace)(nil),               // 24: chatting.Workspace
+	(*CreateWorkspaceRequest)(nil),  // 25: chatting.CreateWorkspaceRequest
+	(*ListWorkspacesResponse)(nil),  // 26: chatting.ListWorkspacesResponse
+	(*WorkspaceMemberRequest)(nil),  // 27: chatting.WorkspaceMemberRequest
+	(*Room)(nil),                    // 28: chatting.Room
+	(*GetChatRoomRequest)(nil),      // 29: chatting.GetChatRoomRequest
+	(*GetChatRoomResponse)(nil),     // 30: chatting.GetChatRoomResponse
+	(*RoomEvent)(nil),               // 31: chatting.RoomEvent
+	(*CreateRoomRequest)(nil),       // 32: chatting.CreateRoomRequest
+	(*GetRoomByNameRequest)(nil),    // 33: chatting.GetRoomByNameRequest
+	(*UpdateRoomRequest)(nil),       // 34: chatting.UpdateRoomRequest
+	(*RemoveRoomRequest)(nil),       // 35: chatting.RemoveRoomRequest
+	(*GrantRoleRequest)(nil),        // 36: chatting.GrantRoleRequest
+	(*RevokeRoleRequest)(nil),       // 37: chatting.RevokeRoleRequest
+	(*CreateInviteRequest)(nil),     // 38: chatting.CreateInviteRequest
+	(*Invite)(nil),                  // 39: chatting.Invite
+	(*JoinByInviteRequest)(nil),     // 40: chatting.JoinByInviteRequest
+	(*Member)(nil),                  // 41: chatting.Member
+	(*ListRoomMembersResponse)(nil), // 42: chatting.ListRoomMembersResponse
+	(*ModerationRequest)(nil),       // 43: chatting.ModerationRequest
+	(*MuteUserRequest)(nil),         // 44: chatting.MuteUserRequest
+	(*RoomRequest)(nil),             // 45: chatting.RoomRequest
+	(*WaitlistEvent)(nil),           // 46: chatting.WaitlistEvent
+	(*SessionRequest)(nil),          // 47: chatting.SessionRequest
+	(*SessionEvent)(nil),            // 48: chatting.SessionEvent
+	(*HistoryRequest)(nil),          // 49: chatting.HistoryRequest
+	(*SearchHistoryRequest)(nil),    // 50: chatting.SearchHistoryRequest
+	(*Message)(nil),                 // 51: chatting.Message
+	(*timestamppb.Timestamp)(nil),   // 52: google.protobuf.Timestamp
 }
 var file_chatting_proto_depIdxs = []int32{
-	0, // 0: chatting.Chatting.Login:input_type -> chatting.Empty
-	0, // 1: chatting.Chatting.Logout:input_type -> chatting.Empty
-	0, // 2: chatting.Chatting.GetChatRoom:input_type -> chatting.Empty
-	3, // 3: chatting.Chatting.CreateRoom:input_type -> chatting.CreateRoomRequest
-	4, // 4: chatting.Chatting.RemoveRoom:input_type -> chatting.RemoveRoomRequest
-	5, // 5: chatting.Chatting.EnterChatRoom:input_type -> chatting.RoomRequest
-	0, // 6: chatting.Chatting.ExitChatRoom:input_type -> chatting.Empty
-	6, // 7: chatting.Chatting.Chatting:input_type -> chatting.Message
-	1, // 8: chatting.Chatting.Login:output_type -> chatting.User
-	0, // 9: chatting.Chatting.Logout:output_type -> chatting.Empty
-	2, // 10: chatting.Chatting.GetChatRoom:output_type -> chatting.Room
-	2, // 11: chatting.Chatting.CreateRoom:output_type -> chatting.Room
-	0, // 12: chatting.Chatting.RemoveRoom:output_type -> chatting.Empty
-	0, // 13: chatting.Chatting.EnterChatRoom:output_type -> chatting.Empty
-	0, // 14: chatting.Chatting.ExitChatRoom:output_type -> chatting.Empty
-	6, // 15: chatting.Chatting.Chatting:output_type -> chatting.Message
-	8, // [8:16] is the sub-list for method output_type
-	0, // [0:8] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	8,  // 0: chatting.BatchGetUsersResponse.users:type_name -> chatting.User
+	3,  // 1: chatting.PresenceEvent.presence:type_name -> chatting.Presence
+	52, // 2: chatting.PresenceEvent.lastSeen:type_name -> google.protobuf.Timestamp
+	8,  // 3: chatting.LoginResponse.user:type_name -> chatting.User
+	52, // 4: chatting.LoginResponse.expiresAt:type_name -> google.protobuf.Timestamp
+	52, // 5: chatting.LoginResponse.sessionExpiresAt:type_name -> google.protobuf.Timestamp
+	52, // 6: chatting.DeviceSession.createdAt:type_name -> google.protobuf.Timestamp
+	52, // 7: chatting.DeviceSession.refreshedAt:type_name -> google.protobuf.Timestamp
+	52, // 8: chatting.DeviceSession.expiresAt:type_name -> google.protobuf.Timestamp
+	21, // 9: chatting.ListSessionsResponse.sessions:type_name -> chatting.DeviceSession
+	52, // 10: chatting.Workspace.createdAt:type_name -> google.protobuf.Timestamp
+	24, // 11: chatting.ListWorkspacesResponse.workspaces:type_name -> chatting.Workspace
+	52, // 12: chatting.Room.createdAt:type_name -> google.protobuf.Timestamp
+	1,  // 13: chatting.Room.visibility:type_name -> chatting.Visibility
+	52, // 14: chatting.Room.lastActivityAt:type_name -> google.protobuf.Timestamp
+	4,  // 15: chatting.GetChatRoomRequest.sort:type_name -> chatting.RoomSort
+	28, // 16: chatting.GetChatRoomResponse.rooms:type_name -> chatting.Room
+	5,  // 17: chatting.RoomEvent.type:type_name -> chatting.RoomEventType
+	28, // 18: chatting.RoomEvent.rooms:type_name -> chatting.Room
+	1,  // 19: chatting.CreateRoomRequest.visibility:type_name -> chatting.Visibility
+	1,  // 20: chatting.UpdateRoomRequest.visibility:type_name -> chatting.Visibility
+	0,  // 21: chatting.GrantRoleRequest.role:type_name -> chatting.Role
+	52, // 22: chatting.Invite.expiresAt:type_name -> google.protobuf.Timestamp
+	0,  // 23: chatting.Member.role:type_name -> chatting.Role
+	3,  // 24: chatting.Member.presence:type_name -> chatting.Presence
+	41, // 25: chatting.ListRoomMembersResponse.members:type_name -> chatting.Member
+	6,  // 26: chatting.SessionRequest.action:type_name -> chatting.SessionAction
+	51, // 27: chatting.SessionRequest.message:type_name -> chatting.Message
+	51, // 28: chatting.SessionEvent.message:type_name -> chatting.Message
+	2,  // 29: chatting.Message.kind:type_name -> chatting.MessageKind
+	17, // 30: chatting.Chatting.Register:input_type -> chatting.RegisterRequest
+	18, // 31: chatting.Chatting.Login:input_type -> chatting.LoginRequest
+	7,  // 32: chatting.Chatting.Logout:input_type -> chatting.Empty
+	20, // 33: chatting.Chatting.RefreshSession:input_type -> chatting.RefreshSessionRequest
+	7,  // 34: chatting.Chatting.ListSessions:input_type -> chatting.Empty
+	23, // 35: chatting.Chatting.RevokeSession:input_type -> chatting.RevokeSessionRequest
+	9,  // 36: chatting.Chatting.SetProfile:input_type -> chatting.SetProfileRequest
+	10, // 37: chatting.Chatting.GetUser:input_type -> chatting.GetUserRequest
+	11, // 38: chatting.Chatting.BatchGetUsers:input_type -> chatting.BatchGetUsersRequest
+	7,  // 39: chatting.Chatting.Heartbeat:input_type -> chatting.Empty
+	14, // 40: chatting.Chatting.SetPresence:input_type -> chatting.SetPresenceRequest
+	15, // 41: chatting.Chatting.WatchPresence:input_type -> chatting.WatchPresenceRequest
+	25, // 42: chatting.Chatting.CreateWorkspace:input_type -> chatting.CreateWorkspaceRequest
+	7,  // 43: chatting.Chatting.ListWorkspaces:input_type -> chatting.Empty
+	27, // 44: chatting.Chatting.AddWorkspaceMember:input_type -> chatting.WorkspaceMemberRequest
+	27, // 45: chatting.Chatting.RemoveWorkspaceMember:input_type -> chatting.WorkspaceMemberRequest
+	29, // 46: chatting.Chatting.GetChatRoom:input_type -> chatting.GetChatRoomRequest
+	7,  // 47: chatting.Chatting.WatchRooms:input_type -> chatting.Empty
+	33, // 48: chatting.Chatting.GetRoomByName:input_type -> chatting.GetRoomByNameRequest
+	32, // 49: chatting.Chatting.CreateRoom:input_type -> chatting.CreateRoomRequest
+	35, // 50: chatting.Chatting.RemoveRoom:input_type -> chatting.RemoveRoomRequest
+	34, // 51: chatting.Chatting.UpdateRoom:input_type -> chatting.UpdateRoomRequest
+	45, // 52: chatting.Chatting.ArchiveRoom:input_type -> chatting.RoomRequest
+	45, // 53: chatting.Chatting.UnarchiveRoom:input_type -> chatting.RoomRequest
+	36, // 54: chatting.Chatting.GrantRole:input_type -> chatting.GrantRoleRequest
+	37, // 55: chatting.Chatting.RevokeRole:input_type -> chatting.RevokeRoleRequest
+	38, // 56: chatting.Chatting.CreateInvite:input_type -> chatting.CreateInviteRequest
+	40, // 57: chatting.Chatting.JoinByInvite:input_type -> chatting.JoinByInviteRequest
+	45, // 58: chatting.Chatting.EnterChatRoom:input_type -> chatting.RoomRequest
+	7,  // 59: chatting.Chatting.ExitChatRoom:input_type -> chatting.Empty
+	45, // 60: chatting.Chatting.JoinWaitlist:input_type -> chatting.RoomRequest
+	45, // 61: chatting.Chatting.ListRoomMembers:input_type -> chatting.RoomRequest
+	43, // 62: chatting.Chatting.KickUser:input_type -> chatting.ModerationRequest
+	43, // 63: chatting.Chatting.BanUser:input_type -> chatting.ModerationRequest
+	43, // 64: chatting.Chatting.UnbanUser:input_type -> chatting.ModerationRequest
+	44, // 65: chatting.Chatting.MuteUser:input_type -> chatting.MuteUserRequest
+	51, // 66: chatting.Chatting.Chatting:input_type -> chatting.Message
+	47, // 67: chatting.Chatting.Session:input_type -> chatting.SessionRequest
+	49, // 68: chatting.Chatting.GetHistory:input_type -> chatting.HistoryRequest
+	50, // 69: chatting.Chatting.SearchHistory:input_type -> chatting.SearchHistoryRequest
+	19, // 70: chatting.Chatting.Register:output_type -> chatting.LoginResponse
+	19, // 71: chatting.Chatting.Login:output_type -> chatting.LoginResponse
+	7,  // 72: chatting.Chatting.Logout:output_type -> chatting.Empty
+	19, // 73: chatting.Chatting.RefreshSession:output_type -> chatting.LoginResponse
+	22, // 74: chatting.Chatting.ListSessions:output_type -> chatting.ListSessionsResponse
+	7,  // 75: chatting.Chatting.RevokeSession:output_type -> chatting.Empty
+	8,  // 76: chatting.Chatting.SetProfile:output_type -> chatting.User
+	8,  // 77: chatting.Chatting.GetUser:output_type -> chatting.User
+	12, // 78: chatting.Chatting.BatchGetUsers:output_type -> chatting.BatchGetUsersResponse
+	13, // 79: chatting.Chatting.Heartbeat:output_type -> chatting.HeartbeatResponse
+	7,  // 80: chatting.Chatting.SetPresence:output_type -> chatting.Empty
+	16, // 81: chatting.Chatting.WatchPresence:output_type -> chatting.PresenceEvent
+	24, // 82: chatting.Chatting.CreateWorkspace:output_type -> chatting.Workspace
+	26, // 83: chatting.Chatting.ListWorkspaces:output_type -> chatting.ListWorkspacesResponse
+	7,  // 84: chatting.Chatting.AddWorkspaceMember:output_type -> chatting.Empty
+	7,  // 85: chatting.Chatting.RemoveWorkspaceMember:output_type -> chatting.Empty
+	30, // 86: chatting.Chatting.GetChatRoom:output_type -> chatting.GetChatRoomResponse
+	31, // 87: chatting.Chatting.WatchRooms:output_type -> chatting.RoomEvent
+	28, // 88: chatting.Chatting.GetRoomByName:output_type -> chatting.Room
+	28, // 89: chatting.Chatting.CreateRoom:output_type -> chatting.Room
+	7,  // 90: chatting.Chatting.RemoveRoom:output_type -> chatting.Empty
+	28, // 91: chatting.Chatting.UpdateRoom:output_type -> chatting.Room
+	28, // 92: chatting.Chatting.ArchiveRoom:output_type -> chatting.Room
+	28, // 93: chatting.Chatting.UnarchiveRoom:output_type -> chatting.Room
+	7,  // 94: chatting.Chatting.GrantRole:output_type -> chatting.Empty
+	7,  // 95: chatting.Chatting.RevokeRole:output_type -> chatting.Empty
+	39, // 96: chatting.Chatting.CreateInvite:output_type -> chatting.Invite
+	28, // 97: chatting.Chatting.JoinByInvite:output_type -> chatting.Room
+	7,  // 98: chatting.Chatting.EnterChatRoom:output_type -> chatting.Empty
+	7,  // 99: chatting.Chatting.ExitChatRoom:output_type -> chatting.Empty
+	46, // 100: chatting.Chatting.JoinWaitlist:output_type -> chatting.WaitlistEvent
+	42, // 101: chatting.Chatting.ListRoomMembers:output_type -> chatting.ListRoomMembersResponse
+	7,  // 102: chatting.Chatting.KickUser:output_type -> chatting.Empty
+	7,  // 103: chatting.Chatting.BanUser:output_type -> chatting.Empty
+	7,  // 104: chatting.Chatting.UnbanUser:output_type -> chatting.Empty
+	7,  // 105: chatting.Chatting.MuteUser:output_type -> chatting.Empty
+	51, // 106: chatting.Chatting.Chatting:output_type -> chatting.Message
+	48, // 107: chatting.Chatting.Session:output_type -> chatting.SessionEvent
+	51, // 108: chatting.Chatting.GetHistory:output_type -> chatting.Message
+	51, // 109: chatting.Chatting.SearchHistory:output_type -> chatting.Message
+	70, // [70:110] is the sub-list for method output_type
+	30, // [30:70] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_chatting_proto_init() }
@@ -414,18 +3691,21 @@ func file_chatting_proto_init() {
 	if File_chatting_proto != nil {
 		return
 	}
+	file_chatting_proto_msgTypes[2].OneofWrappers = []any{}
+	file_chatting_proto_msgTypes[27].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chatting_proto_rawDesc), len(file_chatting_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      7,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_chatting_proto_goTypes,
 		DependencyIndexes: file_chatting_proto_depIdxs,
+		EnumInfos:         file_chatting_proto_enumTypes,
 		MessageInfos:      file_chatting_proto_msgTypes,
 	}.Build()
 	File_chatting_proto = out.File
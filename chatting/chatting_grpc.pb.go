@@ -21,28 +21,92 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Chatting_Login_FullMethodName         = "/chatting.Chatting/Login"
-	Chatting_Logout_FullMethodName        = "/chatting.Chatting/Logout"
-	Chatting_GetChatRoom_FullMethodName   = "/chatting.Chatting/GetChatRoom"
-	Chatting_CreateRoom_FullMethodName    = "/chatting.Chatting/CreateRoom"
-	Chatting_RemoveRoom_FullMethodName    = "/chatting.Chatting/RemoveRoom"
-	Chatting_EnterChatRoom_FullMethodName = "/chatting.Chatting/EnterChatRoom"
-	Chatting_ExitChatRoom_FullMethodName  = "/chatting.Chatting/ExitChatRoom"
-	Chatting_Chatting_FullMethodName      = "/chatting.Chatting/Chatting"
+	Chatting_Register_FullMethodName              = "/chatting.Chatting/Register"
+	Chatting_Login_FullMethodName                 = "/chatting.Chatting/Login"
+	Chatting_Logout_FullMethodName                = "/chatting.Chatting/Logout"
+	Chatting_RefreshSession_FullMethodName        = "/chatting.Chatting/RefreshSession"
+	Chatting_ListSessions_FullMethodName          = "/chatting.Chatting/ListSessions"
+	Chatting_RevokeSession_FullMethodName         = "/chatting.Chatting/RevokeSession"
+	Chatting_SetProfile_FullMethodName            = "/chatting.Chatting/SetProfile"
+	Chatting_GetUser_FullMethodName               = "/chatting.Chatting/GetUser"
+	Chatting_BatchGetUsers_FullMethodName         = "/chatting.Chatting/BatchGetUsers"
+	Chatting_Heartbeat_FullMethodName             = "/chatting.Chatting/Heartbeat"
+	Chatting_SetPresence_FullMethodName           = "/chatting.Chatting/SetPresence"
+	Chatting_WatchPresence_FullMethodName         = "/chatting.Chatting/WatchPresence"
+	Chatting_CreateWorkspace_FullMethodName       = "/chatting.Chatting/CreateWorkspace"
+	Chatting_ListWorkspaces_FullMethodName        = "/chatting.Chatting/ListWorkspaces"
+	Chatting_AddWorkspaceMember_FullMethodName    = "/chatting.Chatting/AddWorkspaceMember"
+	Chatting_RemoveWorkspaceMember_FullMethodName = "/chatting.Chatting/RemoveWorkspaceMember"
+	Chatting_GetChatRoom_FullMethodName           = "/chatting.Chatting/GetChatRoom"
+	Chatting_WatchRooms_FullMethodName            = "/chatting.Chatting/WatchRooms"
+	Chatting_GetRoomByName_FullMethodName         = "/chatting.Chatting/GetRoomByName"
+	Chatting_CreateRoom_FullMethodName            = "/chatting.Chatting/CreateRoom"
+	Chatting_RemoveRoom_FullMethodName            = "/chatting.Chatting/RemoveRoom"
+	Chatting_UpdateRoom_FullMethodName            = "/chatting.Chatting/UpdateRoom"
+	Chatting_ArchiveRoom_FullMethodName           = "/chatting.Chatting/ArchiveRoom"
+	Chatting_UnarchiveRoom_FullMethodName         = "/chatting.Chatting/UnarchiveRoom"
+	Chatting_GrantRole_FullMethodName             = "/chatting.Chatting/GrantRole"
+	Chatting_RevokeRole_FullMethodName            = "/chatting.Chatting/RevokeRole"
+	Chatting_CreateInvite_FullMethodName          = "/chatting.Chatting/CreateInvite"
+	Chatting_JoinByInvite_FullMethodName          = "/chatting.Chatting/JoinByInvite"
+	Chatting_EnterChatRoom_FullMethodName         = "/chatting.Chatting/EnterChatRoom"
+	Chatting_ExitChatRoom_FullMethodName          = "/chatting.Chatting/ExitChatRoom"
+	Chatting_JoinWaitlist_FullMethodName          = "/chatting.Chatting/JoinWaitlist"
+	Chatting_ListRoomMembers_FullMethodName       = "/chatting.Chatting/ListRoomMembers"
+	Chatting_KickUser_FullMethodName              = "/chatting.Chatting/KickUser"
+	Chatting_BanUser_FullMethodName               = "/chatting.Chatting/BanUser"
+	Chatting_UnbanUser_FullMethodName             = "/chatting.Chatting/UnbanUser"
+	Chatting_MuteUser_FullMethodName              = "/chatting.Chatting/MuteUser"
+	Chatting_Chatting_FullMethodName              = "/chatting.Chatting/Chatting"
+	Chatting_Session_FullMethodName               = "/chatting.Chatting/Session"
+	Chatting_GetHistory_FullMethodName            = "/chatting.Chatting/GetHistory"
+	Chatting_SearchHistory_FullMethodName         = "/chatting.Chatting/SearchHistory"
 )
 
 // ChattingClient is the client API for Chatting service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ChattingClient interface {
-	Login(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*User, error)
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	Logout(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
-	GetChatRoom(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Room], error)
+	RefreshSession(ctx context.Context, in *RefreshSessionRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	ListSessions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetProfile(ctx context.Context, in *SetProfileRequest, opts ...grpc.CallOption) (*User, error)
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error)
+	BatchGetUsers(ctx context.Context, in *BatchGetUsersRequest, opts ...grpc.CallOption) (*BatchGetUsersResponse, error)
+	Heartbeat(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HeartbeatResponse, error)
+	SetPresence(ctx context.Context, in *SetPresenceRequest, opts ...grpc.CallOption) (*Empty, error)
+	WatchPresence(ctx context.Context, in *WatchPresenceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PresenceEvent], error)
+	CreateWorkspace(ctx context.Context, in *CreateWorkspaceRequest, opts ...grpc.CallOption) (*Workspace, error)
+	ListWorkspaces(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListWorkspacesResponse, error)
+	AddWorkspaceMember(ctx context.Context, in *WorkspaceMemberRequest, opts ...grpc.CallOption) (*Empty, error)
+	RemoveWorkspaceMember(ctx context.Context, in *WorkspaceMemberRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetChatRoom(ctx context.Context, in *GetChatRoomRequest, opts ...grpc.CallOption) (*GetChatRoomResponse, error)
+	WatchRooms(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoomEvent], error)
+	GetRoomByName(ctx context.Context, in *GetRoomByNameRequest, opts ...grpc.CallOption) (*Room, error)
 	CreateRoom(ctx context.Context, in *CreateRoomRequest, opts ...grpc.CallOption) (*Room, error)
 	RemoveRoom(ctx context.Context, in *RemoveRoomRequest, opts ...grpc.CallOption) (*Empty, error)
+	UpdateRoom(ctx context.Context, in *UpdateRoomRequest, opts ...grpc.CallOption) (*Room, error)
+	ArchiveRoom(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*Room, error)
+	UnarchiveRoom(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*Room, error)
+	GrantRole(ctx context.Context, in *GrantRoleRequest, opts ...grpc.CallOption) (*Empty, error)
+	RevokeRole(ctx context.Context, in *RevokeRoleRequest, opts ...grpc.CallOption) (*Empty, error)
+	CreateInvite(ctx context.Context, in *CreateInviteRequest, opts ...grpc.CallOption) (*Invite, error)
+	JoinByInvite(ctx context.Context, in *JoinByInviteRequest, opts ...grpc.CallOption) (*Room, error)
 	EnterChatRoom(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*Empty, error)
 	ExitChatRoom(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	JoinWaitlist(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WaitlistEvent], error)
+	ListRoomMembers(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*ListRoomMembersResponse, error)
+	KickUser(ctx context.Context, in *ModerationRequest, opts ...grpc.CallOption) (*Empty, error)
+	BanUser(ctx context.Context, in *ModerationRequest, opts ...grpc.CallOption) (*Empty, error)
+	UnbanUser(ctx context.Context, in *ModerationRequest, opts ...grpc.CallOption) (*Empty, error)
+	MuteUser(ctx context.Context, in *MuteUserRequest, opts ...grpc.CallOption) (*Empty, error)
 	Chatting(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[Message, Message], error)
+	Session(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SessionRequest, SessionEvent], error)
+	GetHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Message], error)
+	SearchHistory(ctx context.Context, in *SearchHistoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Message], error)
 }
 
 type chattingClient struct {
@@ -53,9 +117,19 @@ func NewChattingClient(cc grpc.ClientConnInterface) ChattingClient {
 	return &chattingClient{cc}
 }
 
-func (c *chattingClient) Login(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*User, error) {
+func (c *chattingClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(User)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, Chatting_Register_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
 	err := c.cc.Invoke(ctx, Chatting_Login_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -73,13 +147,162 @@ func (c *chattingClient) Logout(ctx context.Context, in *Empty, opts ...grpc.Cal
 	return out, nil
 }
 
-func (c *chattingClient) GetChatRoom(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Room], error) {
+func (c *chattingClient) RefreshSession(ctx context.Context, in *RefreshSessionRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, Chatting_RefreshSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) ListSessions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, Chatting_ListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Chatting_RevokeSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) SetProfile(ctx context.Context, in *SetProfileRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, Chatting_SetProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, Chatting_GetUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) BatchGetUsers(ctx context.Context, in *BatchGetUsersRequest, opts ...grpc.CallOption) (*BatchGetUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetUsersResponse)
+	err := c.cc.Invoke(ctx, Chatting_BatchGetUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) Heartbeat(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HeartbeatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HeartbeatResponse)
+	err := c.cc.Invoke(ctx, Chatting_Heartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) SetPresence(ctx context.Context, in *SetPresenceRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Chatting_SetPresence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) WatchPresence(ctx context.Context, in *WatchPresenceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PresenceEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Chatting_ServiceDesc.Streams[0], Chatting_WatchPresence_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchPresenceRequest, PresenceEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Chatting_WatchPresenceClient = grpc.ServerStreamingClient[PresenceEvent]
+
+func (c *chattingClient) CreateWorkspace(ctx context.Context, in *CreateWorkspaceRequest, opts ...grpc.CallOption) (*Workspace, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Workspace)
+	err := c.cc.Invoke(ctx, Chatting_CreateWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) ListWorkspaces(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListWorkspacesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWorkspacesResponse)
+	err := c.cc.Invoke(ctx, Chatting_ListWorkspaces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) AddWorkspaceMember(ctx context.Context, in *WorkspaceMemberRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Chatting_AddWorkspaceMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) RemoveWorkspaceMember(ctx context.Context, in *WorkspaceMemberRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Chatting_RemoveWorkspaceMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) GetChatRoom(ctx context.Context, in *GetChatRoomRequest, opts ...grpc.CallOption) (*GetChatRoomResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetChatRoomResponse)
+	err := c.cc.Invoke(ctx, Chatting_GetChatRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) WatchRooms(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoomEvent], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &Chatting_ServiceDesc.Streams[0], Chatting_GetChatRoom_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &Chatting_ServiceDesc.Streams[1], Chatting_WatchRooms_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[Empty, Room]{ClientStream: stream}
+	x := &grpc.GenericClientStream[Empty, RoomEvent]{ClientStream: stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -90,7 +313,17 @@ func (c *chattingClient) GetChatRoom(ctx context.Context, in *Empty, opts ...grp
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type Chatting_GetChatRoomClient = grpc.ServerStreamingClient[Room]
+type Chatting_WatchRoomsClient = grpc.ServerStreamingClient[RoomEvent]
+
+func (c *chattingClient) GetRoomByName(ctx context.Context, in *GetRoomByNameRequest, opts ...grpc.CallOption) (*Room, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Room)
+	err := c.cc.Invoke(ctx, Chatting_GetRoomByName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
 func (c *chattingClient) CreateRoom(ctx context.Context, in *CreateRoomRequest, opts ...grpc.CallOption) (*Room, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
@@ -112,221 +345,1047 @@ func (c *chattingClient) RemoveRoom(ctx context.Context, in *RemoveRoomRequest,
 	return out, nil
 }
 
-func (c *chattingClient) EnterChatRoom(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*Empty, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, Chatting_EnterChatRoom_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func (c *chattingClient) UpdateRoom(ctx context.Context, in *UpdateRoomRequest, opts ...grpc.CallOption) (*Room, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Room)
+	err := c.cc.Invoke(ctx, Chatting_UpdateRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) ArchiveRoom(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*Room, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Room)
+	err := c.cc.Invoke(ctx, Chatting_ArchiveRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) UnarchiveRoom(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*Room, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Room)
+	err := c.cc.Invoke(ctx, Chatting_UnarchiveRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) GrantRole(ctx context.Context, in *GrantRoleRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Chatting_GrantRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) RevokeRole(ctx context.Context, in *RevokeRoleRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Chatting_RevokeRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) CreateInvite(ctx context.Context, in *CreateInviteRequest, opts ...grpc.CallOption) (*Invite, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Invite)
+	err := c.cc.Invoke(ctx, Chatting_CreateInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) JoinByInvite(ctx context.Context, in *JoinByInviteRequest, opts ...grpc.CallOption) (*Room, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Room)
+	err := c.cc.Invoke(ctx, Chatting_JoinByInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) EnterChatRoom(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Chatting_EnterChatRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) ExitChatRoom(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Chatting_ExitChatRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) JoinWaitlist(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WaitlistEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Chatting_ServiceDesc.Streams[2], Chatting_JoinWaitlist_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RoomRequest, WaitlistEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Chatting_JoinWaitlistClient = grpc.ServerStreamingClient[WaitlistEvent]
+
+func (c *chattingClient) ListRoomMembers(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*ListRoomMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRoomMembersResponse)
+	err := c.cc.Invoke(ctx, Chatting_ListRoomMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) KickUser(ctx context.Context, in *ModerationRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Chatting_KickUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) BanUser(ctx context.Context, in *ModerationRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Chatting_BanUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) UnbanUser(ctx context.Context, in *ModerationRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Chatting_UnbanUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) MuteUser(ctx context.Context, in *MuteUserRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Chatting_MuteUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chattingClient) Chatting(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[Message, Message], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Chatting_ServiceDesc.Streams[3], Chatting_Chatting_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Message, Message]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Chatting_ChattingClient = grpc.BidiStreamingClient[Message, Message]
+
+func (c *chattingClient) Session(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SessionRequest, SessionEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Chatting_ServiceDesc.Streams[4], Chatting_Session_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SessionRequest, SessionEvent]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Chatting_SessionClient = grpc.BidiStreamingClient[SessionRequest, SessionEvent]
+
+func (c *chattingClient) GetHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Message], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Chatting_ServiceDesc.Streams[5], Chatting_GetHistory_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[HistoryRequest, Message]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Chatting_GetHistoryClient = grpc.ServerStreamingClient[Message]
+
+func (c *chattingClient) SearchHistory(ctx context.Context, in *SearchHistoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Message], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Chatting_ServiceDesc.Streams[6], Chatting_SearchHistory_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SearchHistoryRequest, Message]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Chatting_SearchHistoryClient = grpc.ServerStreamingClient[Message]
+
+// ChattingServer is the server API for Chatting service.
+// All implementations must embed UnimplementedChattingServer
+// for forward compatibility.
+type ChattingServer interface {
+	Register(context.Context, *RegisterRequest) (*LoginResponse, error)
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	Logout(context.Context, *Empty) (*Empty, error)
+	RefreshSession(context.Context, *RefreshSessionRequest) (*LoginResponse, error)
+	ListSessions(context.Context, *Empty) (*ListSessionsResponse, error)
+	RevokeSession(context.Context, *RevokeSessionRequest) (*Empty, error)
+	SetProfile(context.Context, *SetProfileRequest) (*User, error)
+	GetUser(context.Context, *GetUserRequest) (*User, error)
+	BatchGetUsers(context.Context, *BatchGetUsersRequest) (*BatchGetUsersResponse, error)
+	Heartbeat(context.Context, *Empty) (*HeartbeatResponse, error)
+	SetPresence(context.Context, *SetPresenceRequest) (*Empty, error)
+	WatchPresence(*WatchPresenceRequest, grpc.ServerStreamingServer[PresenceEvent]) error
+	CreateWorkspace(context.Context, *CreateWorkspaceRequest) (*Workspace, error)
+	ListWorkspaces(context.Context, *Empty) (*ListWorkspacesResponse, error)
+	AddWorkspaceMember(context.Context, *WorkspaceMemberRequest) (*Empty, error)
+	RemoveWorkspaceMember(context.Context, *WorkspaceMemberRequest) (*Empty, error)
+	GetChatRoom(context.Context, *GetChatRoomRequest) (*GetChatRoomResponse, error)
+	WatchRooms(*Empty, grpc.ServerStreamingServer[RoomEvent]) error
+	GetRoomByName(context.Context, *GetRoomByNameRequest) (*Room, error)
+	CreateRoom(context.Context, *CreateRoomRequest) (*Room, error)
+	RemoveRoom(context.Context, *RemoveRoomRequest) (*Empty, error)
+	UpdateRoom(context.Context, *UpdateRoomRequest) (*Room, error)
+	ArchiveRoom(context.Context, *RoomRequest) (*Room, error)
+	UnarchiveRoom(context.Context, *RoomRequest) (*Room, error)
+	GrantRole(context.Context, *GrantRoleRequest) (*Empty, error)
+	RevokeRole(context.Context, *RevokeRoleRequest) (*Empty, error)
+	CreateInvite(context.Context, *CreateInviteRequest) (*Invite, error)
+	JoinByInvite(context.Context, *JoinByInviteRequest) (*Room, error)
+	EnterChatRoom(context.Context, *RoomRequest) (*Empty, error)
+	ExitChatRoom(context.Context, *Empty) (*Empty, error)
+	JoinWaitlist(*RoomRequest, grpc.ServerStreamingServer[WaitlistEvent]) error
+	ListRoomMembers(context.Context, *RoomRequest) (*ListRoomMembersResponse, error)
+	KickUser(context.Context, *ModerationRequest) (*Empty, error)
+	BanUser(context.Context, *ModerationRequest) (*Empty, error)
+	UnbanUser(context.Context, *ModerationRequest) (*Empty, error)
+	MuteUser(context.Context, *MuteUserRequest) (*Empty, error)
+	Chatting(grpc.BidiStreamingServer[Message, Message]) error
+	Session(grpc.BidiStreamingServer[SessionRequest, SessionEvent]) error
+	GetHistory(*HistoryRequest, grpc.ServerStreamingServer[Message]) error
+	SearchHistory(*SearchHistoryRequest, grpc.ServerStreamingServer[Message]) error
+	mustEmbedUnimplementedChattingServer()
+}
+
+// UnimplementedChattingServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChattingServer struct{}
+
+func (UnimplementedChattingServer) Register(context.Context, *RegisterRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedChattingServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedChattingServer) Logout(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedChattingServer) RefreshSession(context.Context, *RefreshSessionRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshSession not implemented")
+}
+func (UnimplementedChattingServer) ListSessions(context.Context, *Empty) (*ListSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedChattingServer) RevokeSession(context.Context, *RevokeSessionRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedChattingServer) SetProfile(context.Context, *SetProfileRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProfile not implemented")
+}
+func (UnimplementedChattingServer) GetUser(context.Context, *GetUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedChattingServer) BatchGetUsers(context.Context, *BatchGetUsersRequest) (*BatchGetUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetUsers not implemented")
+}
+func (UnimplementedChattingServer) Heartbeat(context.Context, *Empty) (*HeartbeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedChattingServer) SetPresence(context.Context, *SetPresenceRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPresence not implemented")
+}
+func (UnimplementedChattingServer) WatchPresence(*WatchPresenceRequest, grpc.ServerStreamingServer[PresenceEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPresence not implemented")
+}
+func (UnimplementedChattingServer) CreateWorkspace(context.Context, *CreateWorkspaceRequest) (*Workspace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWorkspace not implemented")
+}
+func (UnimplementedChattingServer) ListWorkspaces(context.Context, *Empty) (*ListWorkspacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkspaces not implemented")
+}
+func (UnimplementedChattingServer) AddWorkspaceMember(context.Context, *WorkspaceMemberRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWorkspaceMember not implemented")
+}
+func (UnimplementedChattingServer) RemoveWorkspaceMember(context.Context, *WorkspaceMemberRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWorkspaceMember not implemented")
+}
+func (UnimplementedChattingServer) GetChatRoom(context.Context, *GetChatRoomRequest) (*GetChatRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChatRoom not implemented")
+}
+func (UnimplementedChattingServer) WatchRooms(*Empty, grpc.ServerStreamingServer[RoomEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRooms not implemented")
+}
+func (UnimplementedChattingServer) GetRoomByName(context.Context, *GetRoomByNameRequest) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoomByName not implemented")
+}
+func (UnimplementedChattingServer) CreateRoom(context.Context, *CreateRoomRequest) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoom not implemented")
+}
+func (UnimplementedChattingServer) RemoveRoom(context.Context, *RemoveRoomRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveRoom not implemented")
+}
+func (UnimplementedChattingServer) UpdateRoom(context.Context, *UpdateRoomRequest) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRoom not implemented")
+}
+func (UnimplementedChattingServer) ArchiveRoom(context.Context, *RoomRequest) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveRoom not implemented")
+}
+func (UnimplementedChattingServer) UnarchiveRoom(context.Context, *RoomRequest) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnarchiveRoom not implemented")
+}
+func (UnimplementedChattingServer) GrantRole(context.Context, *GrantRoleRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantRole not implemented")
+}
+func (UnimplementedChattingServer) RevokeRole(context.Context, *RevokeRoleRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeRole not implemented")
+}
+func (UnimplementedChattingServer) CreateInvite(context.Context, *CreateInviteRequest) (*Invite, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInvite not implemented")
+}
+func (UnimplementedChattingServer) JoinByInvite(context.Context, *JoinByInviteRequest) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinByInvite not implemented")
+}
+func (UnimplementedChattingServer) EnterChatRoom(context.Context, *RoomRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnterChatRoom not implemented")
+}
+func (UnimplementedChattingServer) ExitChatRoom(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExitChatRoom not implemented")
+}
+func (UnimplementedChattingServer) JoinWaitlist(*RoomRequest, grpc.ServerStreamingServer[WaitlistEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method JoinWaitlist not implemented")
+}
+func (UnimplementedChattingServer) ListRoomMembers(context.Context, *RoomRequest) (*ListRoomMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoomMembers not implemented")
+}
+func (UnimplementedChattingServer) KickUser(context.Context, *ModerationRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KickUser not implemented")
+}
+func (UnimplementedChattingServer) BanUser(context.Context, *ModerationRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BanUser not implemented")
+}
+func (UnimplementedChattingServer) UnbanUser(context.Context, *ModerationRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnbanUser not implemented")
+}
+func (UnimplementedChattingServer) MuteUser(context.Context, *MuteUserRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuteUser not implemented")
+}
+func (UnimplementedChattingServer) Chatting(grpc.BidiStreamingServer[Message, Message]) error {
+	return status.Errorf(codes.Unimplemented, "method Chatting not implemented")
+}
+func (UnimplementedChattingServer) Session(grpc.BidiStreamingServer[SessionRequest, SessionEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method Session not implemented")
+}
+func (UnimplementedChattingServer) GetHistory(*HistoryRequest, grpc.ServerStreamingServer[Message]) error {
+	return status.Errorf(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedChattingServer) SearchHistory(*SearchHistoryRequest, grpc.ServerStreamingServer[Message]) error {
+	return status.Errorf(codes.Unimplemented, "method SearchHistory not implemented")
+}
+func (UnimplementedChattingServer) mustEmbedUnimplementedChattingServer() {}
+func (UnimplementedChattingServer) testEmbeddedByValue()                  {}
+
+// UnsafeChattingServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChattingServer will
+// result in compilation errors.
+type UnsafeChattingServer interface {
+	mustEmbedUnimplementedChattingServer()
+}
+
+func RegisterChattingServer(s grpc.ServiceRegistrar, srv ChattingServer) {
+	// If the following call pancis, it indicates UnimplementedChattingServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Chatting_ServiceDesc, srv)
+}
+
+func _Chatting_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).Logout(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_RefreshSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).RefreshSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_RefreshSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).RefreshSession(ctx, req.(*RefreshSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).ListSessions(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_RevokeSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).RevokeSession(ctx, req.(*RevokeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_SetProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).SetProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_SetProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).SetProfile(ctx, req.(*SetProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_BatchGetUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).BatchGetUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_BatchGetUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).BatchGetUsers(ctx, req.(*BatchGetUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).Heartbeat(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_SetPresence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPresenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).SetPresence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_SetPresence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).SetPresence(ctx, req.(*SetPresenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_WatchPresence_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPresenceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChattingServer).WatchPresence(m, &grpc.GenericServerStream[WatchPresenceRequest, PresenceEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Chatting_WatchPresenceServer = grpc.ServerStreamingServer[PresenceEvent]
+
+func _Chatting_CreateWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).CreateWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_CreateWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).CreateWorkspace(ctx, req.(*CreateWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_ListWorkspaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).ListWorkspaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_ListWorkspaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).ListWorkspaces(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_AddWorkspaceMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkspaceMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).AddWorkspaceMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_AddWorkspaceMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).AddWorkspaceMember(ctx, req.(*WorkspaceMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_RemoveWorkspaceMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkspaceMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).RemoveWorkspaceMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_RemoveWorkspaceMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).RemoveWorkspaceMember(ctx, req.(*WorkspaceMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_GetChatRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChatRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).GetChatRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_GetChatRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).GetChatRoom(ctx, req.(*GetChatRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_WatchRooms_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChattingServer).WatchRooms(m, &grpc.GenericServerStream[Empty, RoomEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Chatting_WatchRoomsServer = grpc.ServerStreamingServer[RoomEvent]
+
+func _Chatting_GetRoomByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoomByNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).GetRoomByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_GetRoomByName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).GetRoomByName(ctx, req.(*GetRoomByNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_CreateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).CreateRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_CreateRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).CreateRoom(ctx, req.(*CreateRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_RemoveRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).RemoveRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_RemoveRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).RemoveRoom(ctx, req.(*RemoveRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_UpdateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).UpdateRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_UpdateRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).UpdateRoom(ctx, req.(*UpdateRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_ArchiveRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).ArchiveRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_ArchiveRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).ArchiveRoom(ctx, req.(*RoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_UnarchiveRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).UnarchiveRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_UnarchiveRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).UnarchiveRoom(ctx, req.(*RoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_GrantRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantRoleRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ChattingServer).GrantRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_GrantRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).GrantRole(ctx, req.(*GrantRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *chattingClient) ExitChatRoom(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, Chatting_ExitChatRoom_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _Chatting_RevokeRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeRoleRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ChattingServer).RevokeRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_RevokeRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).RevokeRole(ctx, req.(*RevokeRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *chattingClient) Chatting(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[Message, Message], error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &Chatting_ServiceDesc.Streams[1], Chatting_Chatting_FullMethodName, cOpts...)
-	if err != nil {
+func _Chatting_CreateInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInviteRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[Message, Message]{ClientStream: stream}
-	return x, nil
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type Chatting_ChattingClient = grpc.BidiStreamingClient[Message, Message]
-
-// ChattingServer is the server API for Chatting service.
-// All implementations must embed UnimplementedChattingServer
-// for forward compatibility.
-type ChattingServer interface {
-	Login(context.Context, *Empty) (*User, error)
-	Logout(context.Context, *Empty) (*Empty, error)
-	GetChatRoom(*Empty, grpc.ServerStreamingServer[Room]) error
-	CreateRoom(context.Context, *CreateRoomRequest) (*Room, error)
-	RemoveRoom(context.Context, *RemoveRoomRequest) (*Empty, error)
-	EnterChatRoom(context.Context, *RoomRequest) (*Empty, error)
-	ExitChatRoom(context.Context, *Empty) (*Empty, error)
-	Chatting(grpc.BidiStreamingServer[Message, Message]) error
-	mustEmbedUnimplementedChattingServer()
-}
-
-// UnimplementedChattingServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedChattingServer struct{}
-
-func (UnimplementedChattingServer) Login(context.Context, *Empty) (*User, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
-}
-func (UnimplementedChattingServer) Logout(context.Context, *Empty) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
-}
-func (UnimplementedChattingServer) GetChatRoom(*Empty, grpc.ServerStreamingServer[Room]) error {
-	return status.Errorf(codes.Unimplemented, "method GetChatRoom not implemented")
-}
-func (UnimplementedChattingServer) CreateRoom(context.Context, *CreateRoomRequest) (*Room, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateRoom not implemented")
-}
-func (UnimplementedChattingServer) RemoveRoom(context.Context, *RemoveRoomRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveRoom not implemented")
-}
-func (UnimplementedChattingServer) EnterChatRoom(context.Context, *RoomRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method EnterChatRoom not implemented")
-}
-func (UnimplementedChattingServer) ExitChatRoom(context.Context, *Empty) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ExitChatRoom not implemented")
-}
-func (UnimplementedChattingServer) Chatting(grpc.BidiStreamingServer[Message, Message]) error {
-	return status.Errorf(codes.Unimplemented, "method Chatting not implemented")
-}
-func (UnimplementedChattingServer) mustEmbedUnimplementedChattingServer() {}
-func (UnimplementedChattingServer) testEmbeddedByValue()                  {}
-
-// UnsafeChattingServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ChattingServer will
-// result in compilation errors.
-type UnsafeChattingServer interface {
-	mustEmbedUnimplementedChattingServer()
+	if interceptor == nil {
+		return srv.(ChattingServer).CreateInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_CreateInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).CreateInvite(ctx, req.(*CreateInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterChattingServer(s grpc.ServiceRegistrar, srv ChattingServer) {
-	// If the following call pancis, it indicates UnimplementedChattingServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _Chatting_JoinByInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinByInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&Chatting_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(ChattingServer).JoinByInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_JoinByInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).JoinByInvite(ctx, req.(*JoinByInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Chatting_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _Chatting_EnterChatRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoomRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChattingServer).Login(ctx, in)
+		return srv.(ChattingServer).EnterChatRoom(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Chatting_Login_FullMethodName,
+		FullMethod: Chatting_EnterChatRoom_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChattingServer).Login(ctx, req.(*Empty))
+		return srv.(ChattingServer).EnterChatRoom(ctx, req.(*RoomRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Chatting_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Chatting_ExitChatRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChattingServer).Logout(ctx, in)
+		return srv.(ChattingServer).ExitChatRoom(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Chatting_Logout_FullMethodName,
+		FullMethod: Chatting_ExitChatRoom_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChattingServer).Logout(ctx, req.(*Empty))
+		return srv.(ChattingServer).ExitChatRoom(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Chatting_GetChatRoom_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
+func _Chatting_JoinWaitlist_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RoomRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ChattingServer).GetChatRoom(m, &grpc.GenericServerStream[Empty, Room]{ServerStream: stream})
+	return srv.(ChattingServer).JoinWaitlist(m, &grpc.GenericServerStream[RoomRequest, WaitlistEvent]{ServerStream: stream})
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type Chatting_GetChatRoomServer = grpc.ServerStreamingServer[Room]
+type Chatting_JoinWaitlistServer = grpc.ServerStreamingServer[WaitlistEvent]
 
-func _Chatting_CreateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRoomRequest)
+func _Chatting_ListRoomMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoomRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChattingServer).CreateRoom(ctx, in)
+		return srv.(ChattingServer).ListRoomMembers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Chatting_CreateRoom_FullMethodName,
+		FullMethod: Chatting_ListRoomMembers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChattingServer).CreateRoom(ctx, req.(*CreateRoomRequest))
+		return srv.(ChattingServer).ListRoomMembers(ctx, req.(*RoomRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Chatting_RemoveRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveRoomRequest)
+func _Chatting_KickUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChattingServer).RemoveRoom(ctx, in)
+		return srv.(ChattingServer).KickUser(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Chatting_RemoveRoom_FullMethodName,
+		FullMethod: Chatting_KickUser_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChattingServer).RemoveRoom(ctx, req.(*RemoveRoomRequest))
+		return srv.(ChattingServer).KickUser(ctx, req.(*ModerationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Chatting_EnterChatRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RoomRequest)
+func _Chatting_BanUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChattingServer).EnterChatRoom(ctx, in)
+		return srv.(ChattingServer).BanUser(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Chatting_EnterChatRoom_FullMethodName,
+		FullMethod: Chatting_BanUser_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChattingServer).EnterChatRoom(ctx, req.(*RoomRequest))
+		return srv.(ChattingServer).BanUser(ctx, req.(*ModerationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Chatting_ExitChatRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _Chatting_UnbanUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChattingServer).ExitChatRoom(ctx, in)
+		return srv.(ChattingServer).UnbanUser(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Chatting_ExitChatRoom_FullMethodName,
+		FullMethod: Chatting_UnbanUser_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChattingServer).ExitChatRoom(ctx, req.(*Empty))
+		return srv.(ChattingServer).UnbanUser(ctx, req.(*ModerationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chatting_MuteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChattingServer).MuteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chatting_MuteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChattingServer).MuteUser(ctx, req.(*MuteUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -338,6 +1397,35 @@ func _Chatting_Chatting_Handler(srv interface{}, stream grpc.ServerStream) error
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Chatting_ChattingServer = grpc.BidiStreamingServer[Message, Message]
 
+func _Chatting_Session_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ChattingServer).Session(&grpc.GenericServerStream[SessionRequest, SessionEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Chatting_SessionServer = grpc.BidiStreamingServer[SessionRequest, SessionEvent]
+
+func _Chatting_GetHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(HistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChattingServer).GetHistory(m, &grpc.GenericServerStream[HistoryRequest, Message]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Chatting_GetHistoryServer = grpc.ServerStreamingServer[Message]
+
+func _Chatting_SearchHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchHistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChattingServer).SearchHistory(m, &grpc.GenericServerStream[SearchHistoryRequest, Message]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Chatting_SearchHistoryServer = grpc.ServerStreamingServer[Message]
+
 // Chatting_ServiceDesc is the grpc.ServiceDesc for Chatting service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -345,6 +1433,10 @@ var Chatting_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "chatting.Chatting",
 	HandlerType: (*ChattingServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _Chatting_Register_Handler,
+		},
 		{
 			MethodName: "Login",
 			Handler:    _Chatting_Login_Handler,
@@ -353,6 +1445,62 @@ var Chatting_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Logout",
 			Handler:    _Chatting_Logout_Handler,
 		},
+		{
+			MethodName: "RefreshSession",
+			Handler:    _Chatting_RefreshSession_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _Chatting_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _Chatting_RevokeSession_Handler,
+		},
+		{
+			MethodName: "SetProfile",
+			Handler:    _Chatting_SetProfile_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _Chatting_GetUser_Handler,
+		},
+		{
+			MethodName: "BatchGetUsers",
+			Handler:    _Chatting_BatchGetUsers_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _Chatting_Heartbeat_Handler,
+		},
+		{
+			MethodName: "SetPresence",
+			Handler:    _Chatting_SetPresence_Handler,
+		},
+		{
+			MethodName: "CreateWorkspace",
+			Handler:    _Chatting_CreateWorkspace_Handler,
+		},
+		{
+			MethodName: "ListWorkspaces",
+			Handler:    _Chatting_ListWorkspaces_Handler,
+		},
+		{
+			MethodName: "AddWorkspaceMember",
+			Handler:    _Chatting_AddWorkspaceMember_Handler,
+		},
+		{
+			MethodName: "RemoveWorkspaceMember",
+			Handler:    _Chatting_RemoveWorkspaceMember_Handler,
+		},
+		{
+			MethodName: "GetChatRoom",
+			Handler:    _Chatting_GetChatRoom_Handler,
+		},
+		{
+			MethodName: "GetRoomByName",
+			Handler:    _Chatting_GetRoomByName_Handler,
+		},
 		{
 			MethodName: "CreateRoom",
 			Handler:    _Chatting_CreateRoom_Handler,
@@ -361,6 +1509,34 @@ var Chatting_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveRoom",
 			Handler:    _Chatting_RemoveRoom_Handler,
 		},
+		{
+			MethodName: "UpdateRoom",
+			Handler:    _Chatting_UpdateRoom_Handler,
+		},
+		{
+			MethodName: "ArchiveRoom",
+			Handler:    _Chatting_ArchiveRoom_Handler,
+		},
+		{
+			MethodName: "UnarchiveRoom",
+			Handler:    _Chatting_UnarchiveRoom_Handler,
+		},
+		{
+			MethodName: "GrantRole",
+			Handler:    _Chatting_GrantRole_Handler,
+		},
+		{
+			MethodName: "RevokeRole",
+			Handler:    _Chatting_RevokeRole_Handler,
+		},
+		{
+			MethodName: "CreateInvite",
+			Handler:    _Chatting_CreateInvite_Handler,
+		},
+		{
+			MethodName: "JoinByInvite",
+			Handler:    _Chatting_JoinByInvite_Handler,
+		},
 		{
 			MethodName: "EnterChatRoom",
 			Handler:    _Chatting_EnterChatRoom_Handler,
@@ -369,11 +1545,41 @@ var Chatting_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExitChatRoom",
 			Handler:    _Chatting_ExitChatRoom_Handler,
 		},
+		{
+			MethodName: "ListRoomMembers",
+			Handler:    _Chatting_ListRoomMembers_Handler,
+		},
+		{
+			MethodName: "KickUser",
+			Handler:    _Chatting_KickUser_Handler,
+		},
+		{
+			MethodName: "BanUser",
+			Handler:    _Chatting_BanUser_Handler,
+		},
+		{
+			MethodName: "UnbanUser",
+			Handler:    _Chatting_UnbanUser_Handler,
+		},
+		{
+			MethodName: "MuteUser",
+			Handler:    _Chatting_MuteUser_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
-			StreamName:    "GetChatRoom",
-			Handler:       _Chatting_GetChatRoom_Handler,
+			StreamName:    "WatchPresence",
+			Handler:       _Chatting_WatchPresence_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchRooms",
+			Handler:       _Chatting_WatchRooms_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "JoinWaitlist",
+			Handler:       _Chatting_JoinWaitlist_Handler,
 			ServerStreams: true,
 		},
 		{
@@ -382,6 +1588,22 @@ var Chatting_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Session",
+			Handler:       _Chatting_Session_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetHistory",
+			Handler:       _Chatting_GetHistory_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SearchHistory",
+			Handler:       _Chatting_SearchHistory_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "chatting.proto",
 }
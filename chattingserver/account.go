@@ -0,0 +1,93 @@
+package chattingserver
+
+import (
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	minUsernameLength = 3
+	maxUsernameLength = 32
+	minPasswordLength = 8
+	// argon2 hashes the whole password, so keep it bounded
+	maxPasswordLength = 1024
+)
+
+// errBadCredentials is returned for unknown users and wrong passwords alike.
+var errBadCredentials = status.Error(codes.Unauthenticated, "invalid username or password")
+
+// dummyHash is verified against for unknown usernames so that they take as
+// long to reject as wrong passwords.
+var dummyHash = sync.OnceValue(func() string {
+	hash, _ := HashPassword("not a password")
+	return hash
+})
+
+func validUsername(username string) bool {
+	if len(username) < minUsernameLength || len(username) > maxUsernameLength {
+		return false
+	}
+
+	for _, r := range username {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '_', r == '-', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// RegisterUser creates an account.
+func (c *chattingServer) RegisterUser(username string, password string) (*Account, error) {
+	if !validUsername(username) {
+		return nil, status.Errorf(codes.InvalidArgument, "username must be %v to %v letters, digits, '_', '-' or '.'", minUsernameLength, maxUsernameLength)
+	}
+	if utf8.RuneCountInString(password) < minPasswordLength {
+		return nil, status.Errorf(codes.InvalidArgument, "password must be at least %v characters", minPasswordLength)
+	}
+	if len(password) > maxPasswordLength {
+		return nil, status.Errorf(codes.InvalidArgument, "password must be at most %v bytes", maxPasswordLength)
+	}
+
+	hash, err := HashPassword(password)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can not hash password: %v", err)
+	}
+
+	account := &Account{
+		UserId:       c.ids.NextID(),
+		Username:     username,
+		PasswordHash: hash,
+		CreatedAt:    time.Now(),
+	}
+	if err := c.users.Create(account); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// LoginUser checks the credentials and returns the account.
+func (c *chattingServer) LoginUser(username string, password string) (*Account, error) {
+	if len(password) > maxPasswordLength {
+		return nil, errBadCredentials
+	}
+
+	account, err := c.users.FindByUsername(username)
+	if err != nil {
+		VerifyPassword(dummyHash(), password)
+		return nil, errBadCredentials
+	}
+
+	if !VerifyPassword(account.PasswordHash, password) {
+		return nil, errBadCredentials
+	}
+
+	return account, nil
+}
@@ -0,0 +1,230 @@
+package chattingserver
+
+import (
+	"context"
+	pb "grpc-example/chatting"
+	"grpc-example/tlsconfig"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// callInfo is what the identity middleware learned about a call from its
+// metadata.
+type callInfo struct {
+	// userId and sessionId are 0 for anonymous calls.
+	userId    int64
+	sessionId int64
+	// deviceId is the session, or the connection of users identified by
+	// their client certificate.
+	deviceId    int64
+	workspaceId int64
+	roomId      int64
+	hasRoom     bool
+}
+
+type callInfoKey struct{}
+
+func callInfoFrom(ctx context.Context) *callInfo {
+	info, _ := ctx.Value(callInfoKey{}).(*callInfo)
+	if info == nil {
+		return &callInfo{workspaceId: defaultWorkspaceId}
+	}
+	return info
+}
+
+// UserIdFrom returns the user authenticated for the call, or false for
+// anonymous calls.
+func UserIdFrom(ctx context.Context) (int64, bool) {
+	info := callInfoFrom(ctx)
+	return info.userId, info.userId != 0
+}
+
+// SessionIdFrom returns the session the call was authenticated with, or 0
+// for anonymous calls.
+func SessionIdFrom(ctx context.Context) int64 {
+	return callInfoFrom(ctx).sessionId
+}
+
+// DeviceIdFrom returns the device the call was made from, which is its
+// session or, for users identified by their client certificate, its
+// connection. It is 0 for anonymous calls.
+func DeviceIdFrom(ctx context.Context) int64 {
+	return callInfoFrom(ctx).deviceId
+}
+
+// WorkspaceIdFrom returns the workspace the call is made in.
+func WorkspaceIdFrom(ctx context.Context) int64 {
+	return callInfoFrom(ctx).workspaceId
+}
+
+// RoomIdFrom returns the room named by the room_id metadata, or false when
+// none was sent.
+func RoomIdFrom(ctx context.Context) (int64, bool) {
+	info := callInfoFrom(ctx)
+	return info.roomId, info.hasRoom
+}
+
+// bearerToken returns the token of the "authorization: Bearer <token>"
+// metadata, if a non empty one was sent.
+func bearerToken(md metadata.MD) (string, bool) {
+	for _, value := range md.Get("authorization") {
+		scheme, token, ok := strings.Cut(value, " ")
+		token = strings.TrimSpace(token)
+		if ok && strings.EqualFold(scheme, "bearer") && token != "" {
+			return token, true
+		}
+	}
+	return "", false
+}
+
+// certUsername returns the username of the verified client certificate of
+// the call, if it identifies a user.
+func certUsername(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 {
+		return "", false
+	}
+
+	subject := info.State.VerifiedChains[0][0].Subject
+	if !slices.Contains(subject.OrganizationalUnit, tlsconfig.UserUnit) || subject.CommonName == "" {
+		return "", false
+	}
+	return subject.CommonName, true
+}
+
+// idMetadata parses the id sent as key, if any.
+func idMetadata(md metadata.MD, key string) (int64, bool, error) {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return 0, false, nil
+	}
+
+	id, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil {
+		return 0, false, status.Errorf(codes.InvalidArgument, "invalid %v metadata %q", key, values[0])
+	}
+	return id, true, nil
+}
+
+// credentialMethods hand out session tokens. They are always made
+// anonymously, so that a stale token sent along does not keep the caller
+// from getting a new one.
+var credentialMethods = map[string]struct{}{
+	pb.Chatting_Register_FullMethodName:       {},
+	pb.Chatting_Login_FullMethodName:          {},
+	pb.Chatting_RefreshSession_FullMethodName: {},
+}
+
+// identify returns ctx carrying the callInfo of the call to method. Calls are
+// made by the user of their token or else of their client certificate; they
+// have a session only in the first case. Calls without either go on
+// anonymously; it is up to the handler to require a user.
+func (c *chattingServer) identify(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	info := &callInfo{workspaceId: defaultWorkspaceId}
+
+	_, anonymous := credentialMethods[method]
+
+	if token, ok := bearerToken(md); ok && !anonymous {
+		userId, sessionId, err := c.tokens.Verify(token)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.RLock()
+		revoked := c.sessionRevoked(sessionId)
+		c.mu.RUnlock()
+		if revoked {
+			return nil, errSessionRevoked
+		}
+
+		info.userId = userId
+		info.sessionId = sessionId
+		info.deviceId = sessionId
+	} else if username, ok := certUsername(ctx); ok && !anonymous {
+		account, err := c.users.FindByUsername(username)
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "no user %v for the client certificate", username)
+		}
+
+		info.userId = account.UserId
+		if cn, ok := connFrom(ctx); ok {
+			cn.mu.Lock()
+			cn.userId = account.UserId
+			cn.mu.Unlock()
+			info.deviceId = cn.deviceId
+		} else {
+			// without the ConnHandler every call is a device of its own
+			info.deviceId = c.ids.NextID()
+		}
+	}
+
+	workspaceId, ok, err := idMetadata(md, "workspace_id")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		info.workspaceId = workspaceId
+	}
+
+	info.roomId, info.hasRoom, err = idMetadata(md, "room_id")
+	if err != nil {
+		return nil, err
+	}
+
+	return context.WithValue(ctx, callInfoKey{}, info), nil
+}
+
+func (c *chattingServer) IdentityUnaryMiddleware() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ interface{}, err error) {
+		ctx, err = c.identify(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (c *chattingServer) IdentityStreamMiddleware() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := c.identify(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		// the stream ends together with its session
+		if session := c.FindSession(SessionIdFrom(ctx)); session != nil {
+			var cancel context.CancelCauseFunc
+			ctx, cancel = context.WithCancelCause(ctx)
+			defer cancel(nil)
+
+			stop := context.AfterFunc(session.ctx, func() { cancel(context.Cause(session.ctx)) })
+			defer stop()
+		}
+
+		return handler(srv, &identifiedServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// identifiedServerStream is a stream whose context carries its callInfo.
+type identifiedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identifiedServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,143 @@
+package chattingserver
+
+import (
+	"fmt"
+	pb "grpc-example/chatting"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EnterRoom adds the device of userId to the room. The first device of the
+// user entering is announced to the room. A device entering again ends the
+// stream of its earlier entry.
+func (c *chattingServer) EnterRoom(workspaceId int64, userId int64, deviceId int64, roomId int64) (*Room, *UserInRoom, error) {
+	// streams outlive the check of their workspace when they opened
+	if err := c.CheckWorkspace(workspaceId, userId); err != nil {
+		return nil, nil, err
+	}
+
+	room, err := c.FindRoom(workspaceId, roomId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	room.mu.Lock()
+	if room.IsClosed() {
+		room.mu.Unlock()
+		return nil, nil, removedError(roomId)
+	}
+	if room.IsBanned(userId) {
+		room.mu.Unlock()
+		return nil, nil, bannedError(roomId)
+	}
+	if !room.CanView(userId) {
+		room.mu.Unlock()
+		return nil, nil, status.Errorf(codes.PermissionDenied, "room %v is private; join it with an invite", roomId)
+	}
+	if room.IsFull(userId) {
+		room.mu.Unlock()
+		return nil, nil, status.Errorf(codes.ResourceExhausted, "room %v is full; join the waitlist", roomId)
+	}
+	delete(room.Reserved, userId)
+	devices, entered := room.Users[userId]
+	if !entered {
+		devices = map[int64]*UserInRoom{}
+		room.Users[userId] = devices
+	}
+	if replaced, ok := devices[deviceId]; ok && replaced.cancel != nil {
+		// the stream of the earlier entry would no longer receive the room
+		replaced.cancel(status.Errorf(codes.Aborted, "room %v was entered again from this device", roomId))
+	}
+	user := NewUserInRoom()
+	devices[deviceId] = user
+	if room.RoleOf(userId) == pb.Role_ROLE_UNSPECIFIED {
+		room.Roles[userId] = pb.Role_ROLE_MEMBER
+	}
+	room.mu.Unlock()
+
+	if !entered {
+		c.PublishEvent(room, pb.MessageKind_MESSAGE_KIND_JOIN, userId, c.nameOf(userId)+" joined")
+		c.BroadcastRoomEvent(pb.RoomEventType_ROOM_EVENT_MEMBER_COUNT_CHANGED, room)
+	}
+
+	return room, user, nil
+}
+
+// ExitRoom removes the device of userId from the room. The last device of the
+// user leaving is announced to the room.
+func (c *chattingServer) ExitRoom(workspaceId int64, userId int64, deviceId int64, roomId int64) error {
+	room, err := c.FindRoom(workspaceId, roomId)
+	if err != nil {
+		return err
+	}
+
+	room.mu.Lock()
+	devices, ok := room.Users[userId]
+	_, left := devices[deviceId]
+	if ok {
+		delete(devices, deviceId)
+		left = left && len(devices) == 0
+		if left {
+			delete(room.Users, userId)
+			room.admitWaiters()
+		}
+	}
+	room.mu.Unlock()
+
+	if left {
+		c.PublishEvent(room, pb.MessageKind_MESSAGE_KIND_LEAVE, userId, c.nameOf(userId)+" left")
+		c.BroadcastRoomEvent(pb.RoomEventType_ROOM_EVENT_MEMBER_COUNT_CHANGED, room)
+	}
+
+	return nil
+}
+
+// ExitRooms removes the device of userId from every room it is in.
+func (c *chattingServer) ExitRooms(userId int64, deviceId int64) {
+	c.mu.RLock()
+	rooms := make([]*Room, 0, len(c.Rooms))
+	for _, room := range c.Rooms {
+		rooms = append(rooms, room)
+	}
+	c.mu.RUnlock()
+
+	for _, room := range rooms {
+		room.mu.Lock()
+		_, ok := room.Users[userId][deviceId]
+		room.mu.Unlock()
+		if ok {
+			c.ExitRoom(room.WorkspaceId, userId, deviceId, room.RoomId)
+		}
+	}
+}
+
+// Receive handles a message userId sent to the room through the stream of
+// user. Messages the room does not accept are answered with a notice.
+func (c *chattingServer) Receive(room *Room, user *UserInRoom, userId int64, in *pb.Message) {
+	room.mu.Lock()
+	current := room.InRoom(userId, user)
+	archived := room.Archived
+	until, muted := room.MutedUntil(userId)
+	room.mu.Unlock()
+	if !current {
+		room.Notify(user, "you are not in this room")
+		return
+	}
+	if archived {
+		room.Notify(user, "this room is archived and read-only")
+		return
+	}
+	if muted {
+		room.Notify(user, fmt.Sprintf("you are muted until %v", until.Format(time.DateTime)))
+		return
+	}
+
+	c.PublishOnce(room, user, &pb.Message{
+		Msg:         in.Msg,
+		UserId:      userId,
+		DedupKey:    in.DedupKey,
+		DisplayName: c.DisplayName(userId),
+	})
+}
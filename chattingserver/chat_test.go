@@ -0,0 +1,84 @@
+package chattingserver
+
+import (
+	"context"
+	pb "grpc-example/chatting"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// events returns the kinds of the join and leave events among msgs.
+func events(msgs []*pb.Message) []pb.MessageKind {
+	var kinds []pb.MessageKind
+	for _, msg := range msgs {
+		if msg.Kind == pb.MessageKind_MESSAGE_KIND_JOIN || msg.Kind == pb.MessageKind_MESSAGE_KIND_LEAVE {
+			kinds = append(kinds, msg.Kind)
+		}
+	}
+	return kinds
+}
+
+func TestMultiDeviceFanOut(t *testing.T) {
+	c := newTestServer(t)
+	room := newTestRoom(t, c, 1)
+	bob := enter(t, c, room, 2, 20)
+	phone := enter(t, c, room, 1, 10)
+	laptop := enter(t, c, room, 1, 11)
+
+	c.Receive(room, phone, 1, &pb.Message{Msg: "hi"})
+
+	// every device, the ones of the sender included, gets the message
+	for name, user := range map[string]*UserInRoom{"bob": bob, "phone": phone, "laptop": laptop} {
+		if got := chats(room.Drain(user)); !slices.Equal(got, []string{"hi"}) {
+			t.Errorf("%v got %v, want the message", name, got)
+		}
+	}
+
+	if err := c.ExitRoom(defaultWorkspaceId, 1, 10, room.RoomId); err != nil {
+		t.Fatal(err)
+	}
+	c.Receive(room, laptop, 1, &pb.Message{Msg: "still here"})
+	if got := chats(room.Drain(bob)); !slices.Equal(got, []string{"still here"}) {
+		t.Errorf("bob got %v, want the message of the remaining device", got)
+	}
+
+	if err := c.ExitRoom(defaultWorkspaceId, 1, 11, room.RoomId); err != nil {
+		t.Fatal(err)
+	}
+
+	// only the first device entering and the last leaving are announced
+	history, err := room.HistoryRange(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []pb.MessageKind{pb.MessageKind_MESSAGE_KIND_JOIN, pb.MessageKind_MESSAGE_KIND_JOIN, pb.MessageKind_MESSAGE_KIND_LEAVE}
+	if got := events(history); !slices.Equal(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
+func TestEnterRoomAgain(t *testing.T) {
+	c := newTestServer(t)
+	room := newTestRoom(t, c, 1)
+	earlier := enter(t, c, room, 1, 10)
+	earlierStream := stream(room, earlier)
+
+	again := enter(t, c, room, 1, 10)
+
+	if cause := context.Cause(earlierStream); status.Code(cause) != codes.Aborted {
+		t.Errorf("earlier stream ended with %v, want Aborted", cause)
+	}
+
+	c.Receive(room, earlier, 1, &pb.Message{Msg: "stale"})
+	if got := notices(room.Drain(earlier)); len(got) != 1 {
+		t.Errorf("earlier entry got notices %v, want the refusal", got)
+	}
+
+	c.Receive(room, again, 1, &pb.Message{Msg: "hi"})
+	if got := chats(room.Drain(again)); !slices.Equal(got, []string{"hi"}) {
+		t.Errorf("entry got %v, want the message", got)
+	}
+}
@@ -0,0 +1,66 @@
+package chattingserver
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/stats"
+)
+
+// conn is a client connection. Users identified by their client certificate
+// have no session, so the connection is their device instead.
+type conn struct {
+	deviceId int64
+
+	mu sync.Mutex
+	// userId is the user of the client certificate, once a call was made
+	userId int64
+}
+
+type connKey struct{}
+
+func connFrom(ctx context.Context) (*conn, bool) {
+	c, ok := ctx.Value(connKey{}).(*conn)
+	return c, ok
+}
+
+// connHandler gives every connection a device id and takes its devices out of
+// their rooms once it closes.
+type connHandler struct {
+	c *chattingServer
+}
+
+// ConnHandler has to be installed for users identified by their client
+// certificate to enter rooms.
+func (c *chattingServer) ConnHandler() stats.Handler {
+	return &connHandler{c: c}
+}
+
+func (h *connHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return context.WithValue(ctx, connKey{}, &conn{deviceId: h.c.ids.NextID()})
+}
+
+func (h *connHandler) HandleConn(ctx context.Context, s stats.ConnStats) {
+	if _, ok := s.(*stats.ConnEnd); !ok {
+		return
+	}
+
+	cn, ok := connFrom(ctx)
+	if !ok {
+		return
+	}
+
+	cn.mu.Lock()
+	userId := cn.userId
+	cn.mu.Unlock()
+
+	if userId != 0 {
+		h.c.ExitRooms(userId, cn.deviceId)
+	}
+}
+
+func (h *connHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (h *connHandler) HandleRPC(context.Context, stats.RPCStats) {}
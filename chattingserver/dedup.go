@@ -0,0 +1,121 @@
+package chattingserver
+
+import (
+	pb "grpc-example/chatting"
+	"slices"
+)
+
+// dedupWindowSize is the number of recent dedup keys remembered per sender
+// and room.
+const dedupWindowSize = 256
+
+// dedupSender is a sender in a room, which has a dedup window of its own.
+type dedupSender struct {
+	roomId int64
+	userId int64
+}
+
+// dedupWindow remembers the messages published for the most recent dedup keys
+// of a single sender in a room. A key maps to nil while its message is being
+// published.
+type dedupWindow struct {
+	messages map[string]*pb.Message
+	keys     []string // oldest first
+}
+
+func newDedupWindow() *dedupWindow {
+	return &dedupWindow{messages: map[string]*pb.Message{}}
+}
+
+func (w *dedupWindow) lookup(key string) (*pb.Message, bool) {
+	msg, ok := w.messages[key]
+	return msg, ok
+}
+
+func (w *dedupWindow) remember(key string, msg *pb.Message) {
+	if _, ok := w.messages[key]; ok {
+		w.messages[key] = msg
+		return
+	}
+
+	if len(w.keys) == dedupWindowSize {
+		delete(w.messages, w.keys[0])
+		w.keys = w.keys[1:]
+	}
+
+	w.keys = append(w.keys, key)
+	w.messages[key] = msg
+}
+
+func (w *dedupWindow) forget(key string) {
+	if _, ok := w.messages[key]; !ok {
+		return
+	}
+
+	delete(w.messages, key)
+	w.keys = slices.DeleteFunc(w.keys, func(k string) bool { return k == key })
+}
+
+// PublishOnce posts msg to room unless its sender recently published a
+// message with the same dedup key there. A repeat is dropped and the original
+// message, carrying the original message id, is redelivered to user alone; a
+// repeat of a message still being published is dropped, as the original
+// reaches user with the rest of the room. Messages the posting policy of the
+// room refuses are answered with a notice to user and may be retried.
+func (c *chattingServer) PublishOnce(room *Room, user *UserInRoom, msg *pb.Message) {
+	if msg.DedupKey == "" {
+		msg.MessageId = c.NextMessageId()
+		if blocked := room.Post(msg); blocked != "" {
+			room.Notify(user, blocked)
+		}
+		return
+	}
+
+	sender := dedupSender{roomId: room.RoomId, userId: msg.UserId}
+
+	c.dedupMu.Lock()
+	window, ok := c.dedup[sender]
+	if !ok {
+		window = newDedupWindow()
+		c.dedup[sender] = window
+	}
+	original, repeat := window.lookup(msg.DedupKey)
+	if !repeat {
+		window.remember(msg.DedupKey, nil)
+	}
+	c.dedupMu.Unlock()
+
+	if repeat {
+		if original != nil {
+			room.Deliver(user, original)
+		}
+		return
+	}
+
+	msg.MessageId = c.NextMessageId()
+	blocked := room.Post(msg)
+
+	c.dedupMu.Lock()
+	if blocked != "" {
+		window.forget(msg.DedupKey)
+	} else {
+		window.remember(msg.DedupKey, msg)
+	}
+	c.dedupMu.Unlock()
+
+	if blocked != "" {
+		room.Notify(user, blocked)
+	}
+}
+
+// forgetDedup drops the dedup windows of the senders drop reports.
+func (c *chattingServer) forgetDedup(drop func(sender dedupSender) bool) {
+	c.dedupMu.Lock()
+	defer c.dedupMu.Unlock()
+
+	for sender := range c.dedup {
+		if drop(sender) {
+			delete(c.dedup, sender)
+		}
+	}
+}
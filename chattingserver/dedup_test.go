@@ -0,0 +1,106 @@
+package chattingserver
+
+import (
+	pb "grpc-example/chatting"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestPublishOnceDropsRepeats(t *testing.T) {
+	c := newTestServer(t)
+	room := newTestRoom(t, c, 1)
+	alice := enter(t, c, room, 1, 10)
+
+	c.PublishOnce(room, alice, &pb.Message{Msg: "hi", UserId: 1, DedupKey: "k"})
+	c.PublishOnce(room, alice, &pb.Message{Msg: "hi", UserId: 1, DedupKey: "k"})
+
+	got := room.Drain(alice)
+	if want := []string{"hi", "hi"}; !slices.Equal(chats(got), want) {
+		t.Fatalf("alice got %v, want the message and its redelivery", chats(got))
+	}
+	original, repeat := got[len(got)-2], got[len(got)-1]
+	if repeat != original {
+		t.Errorf("repeat delivered %v, want the original %v", repeat, original)
+	}
+
+	history, err := room.HistoryRange(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := chats(history); len(got) != 1 {
+		t.Errorf("history = %v, want one message", got)
+	}
+}
+
+func TestPublishOncePerRoom(t *testing.T) {
+	c := newTestServer(t)
+	first := newTestRoom(t, c, 1)
+	second := NewRoom("other", 1)
+	second.Slug = "other"
+	second.WorkspaceId = defaultWorkspaceId
+	if _, err := c.CreateRoomId(second); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, room := range []*Room{first, second} {
+		user := enter(t, c, room, 1, 10)
+		c.PublishOnce(room, user, &pb.Message{Msg: "hi", UserId: 1, DedupKey: "k"})
+
+		history, err := room.HistoryRange(0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := chats(history); len(got) != 1 {
+			t.Errorf("room %v history = %v, want the message", room.RoomId, got)
+		}
+	}
+}
+
+func TestPublishOnceRetriesRefused(t *testing.T) {
+	c := newTestServer(t)
+	room := newTestRoom(t, c, 1)
+	bob := enter(t, c, room, 2, 20)
+
+	room.mu.Lock()
+	room.Announcement = true
+	room.mu.Unlock()
+
+	c.PublishOnce(room, bob, &pb.Message{Msg: "hi", UserId: 2, DedupKey: "k"})
+	if got := notices(room.Drain(bob)); len(got) != 1 {
+		t.Fatalf("bob got notices %v, want one refusal", got)
+	}
+
+	room.mu.Lock()
+	room.Announcement = false
+	room.mu.Unlock()
+
+	c.PublishOnce(room, bob, &pb.Message{Msg: "hi", UserId: 2, DedupKey: "k"})
+	if got := chats(room.Drain(bob)); !slices.Equal(got, []string{"hi"}) {
+		t.Errorf("bob got %v after the retry, want the message", got)
+	}
+}
+
+func TestPublishOnceConcurrent(t *testing.T) {
+	c := newTestServer(t)
+	room := newTestRoom(t, c, 1)
+	alice := enter(t, c, room, 1, 10)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.PublishOnce(room, alice, &pb.Message{Msg: "hi", UserId: 1, DedupKey: "k"})
+		}()
+	}
+	wg.Wait()
+
+	history, err := room.HistoryRange(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := chats(history); len(got) != 1 {
+		t.Errorf("history = %v, want one message", got)
+	}
+}
@@ -7,194 +7,1275 @@ import (
 	pb "grpc-example/chatting"
 	"io"
 	"log"
-	"strconv"
+	"sort"
+	"sync"
+	"time"
 
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func (s *chattingServer) Login(_ context.Context, _ *pb.Empty) (*pb.User, error) {
-	fmt.Println("login")
-	userId, err := s.LoginUser()
+func (s *chattingServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.LoginResponse, error) {
+	account, err := s.RegisterUser(req.Username, req.Password)
 	if err != nil {
-		return &pb.User{}, err
+		return nil, err
+	}
+
+	log.Default().Printf("user %v (%v) registered\n", account.UserId, account.Username)
+
+	return s.newSession(ctx, account)
+}
+
+func (s *chattingServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	account, err := s.LoginUser(req.Username, req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Default().Printf("user %v (%v) login\n", account.UserId, account.Username)
+
+	return s.newSession(ctx, account)
+}
+
+// newSession starts a session of the account on the device making the call.
+func (s *chattingServer) newSession(ctx context.Context, account *Account) (*pb.LoginResponse, error) {
+	session, refreshToken, err := s.StartSession(account.UserId, deviceOf(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sessionResponse(account, session, refreshToken), nil
+}
+
+// sessionResponse issues a session token for the session.
+func (s *chattingServer) sessionResponse(account *Account, session *Session, refreshToken string) *pb.LoginResponse {
+	token, expiresAt := s.tokens.Issue(account.UserId, session.SessionId)
+
+	return &pb.LoginResponse{
+		User:             account.ToProto(),
+		Token:            token,
+		ExpiresAt:        timestamppb.New(expiresAt),
+		RefreshToken:     refreshToken,
+		SessionId:        session.SessionId,
+		SessionExpiresAt: timestamppb.New(session.ExpiresAt),
+	}
+}
+
+func (s *chattingServer) Logout(ctx context.Context, _ *pb.Empty) (*pb.Empty, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// sessions of other servers are only known by their token, which
+	// expires soon enough
+	s.EndSession(userId, SessionIdFrom(ctx), errLoggedOut)
+
+	return nil, nil
+}
+
+// RefreshSession needs no session token, as the one of the caller may have
+// expired already.
+func (s *chattingServer) RefreshSession(_ context.Context, req *pb.RefreshSessionRequest) (*pb.LoginResponse, error) {
+	session, refreshToken, err := s.RenewSession(req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.users.FindById(session.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sessionResponse(account, session, refreshToken), nil
+}
+
+func (s *chattingServer) ListSessions(ctx context.Context, _ *pb.Empty) (*pb.ListSessionsResponse, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var sessions []*pb.DeviceSession
+	for _, session := range s.UserSessions(userId) {
+		sessions = append(sessions, session.ToProto(SessionIdFrom(ctx)))
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].SessionId < sessions[j].SessionId
+	})
+
+	return &pb.ListSessionsResponse{Sessions: sessions}, nil
+}
+
+func (s *chattingServer) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.Empty, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.EndSession(userId, req.SessionId, errSessionRevoked); err != nil {
+		return nil, err
+	}
+
+	log.Default().Printf("user %v revoked session %v\n", userId, req.SessionId)
+
+	return nil, nil
+}
+
+func (s *chattingServer) SetProfile(ctx context.Context, req *pb.SetProfileRequest) (*pb.User, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.UpdateProfile(userId, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return account.ToProto(), nil
+}
+
+func (s *chattingServer) GetUser(_ context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	var account *Account
+	var err error
+	switch {
+	case req.UserId != 0:
+		account, err = s.users.FindById(req.UserId)
+	case req.Username != "":
+		account, err = s.users.FindByUsername(req.Username)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "userId or username required")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return account.ToProto(), nil
+}
+
+func (s *chattingServer) BatchGetUsers(_ context.Context, req *pb.BatchGetUsersRequest) (*pb.BatchGetUsersResponse, error) {
+	if len(req.UserIds) > maxBatchGetUsers {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %v users may be looked up at once", maxBatchGetUsers)
+	}
+
+	var users []*pb.User
+	for _, userId := range req.UserIds {
+		account, err := s.users.FindById(userId)
+		if err != nil {
+			continue
+		}
+		users = append(users, account.ToProto())
+	}
+
+	return &pb.BatchGetUsersResponse{Users: users}, nil
+}
+
+func (s *chattingServer) Heartbeat(ctx context.Context, _ *pb.Empty) (*pb.HeartbeatResponse, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Beat(userId)
+
+	return &pb.HeartbeatResponse{IntervalSeconds: int32(HeartbeatInterval / time.Second)}, nil
+}
+
+func (s *chattingServer) SetPresence(ctx context.Context, req *pb.SetPresenceRequest) (*pb.Empty, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.SetAway(userId, req.Away)
+
+	return nil, nil
+}
+
+func (s *chattingServer) WatchPresence(req *pb.WatchPresenceRequest, stream pb.Chatting_WatchPresenceServer) error {
+	ctx := stream.Context()
+	if _, err := s.GetUserId(&ctx); err != nil {
+		return err
+	}
+
+	if len(req.UserIds) > maxWatchPresence {
+		return status.Errorf(codes.InvalidArgument, "at most %v users may be watched at once", maxWatchPresence)
+	}
+
+	w, snapshot := s.WatchPresenceEvents(req.UserIds)
+	defer s.UnwatchPresenceEvents(w)
+
+	for _, event := range snapshot {
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case event := <-w.events:
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case <-w.lagged:
+			return status.Error(codes.ResourceExhausted, "too many presence events pending; watch again")
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+}
+
+func (s *chattingServer) CreateWorkspace(ctx context.Context, req *pb.CreateWorkspaceRequest) (*pb.Workspace, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "workspace name can not be empty")
+	}
+
+	workspace := NewWorkspace(req.Name, userId)
+	if _, err := s.CreateWorkspaceId(workspace); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return workspace.ToProto(userId), nil
+}
+
+func (s *chattingServer) ListWorkspaces(ctx context.Context, _ *pb.Empty) (*pb.ListWorkspacesResponse, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListWorkspacesResponse{Workspaces: s.MemberWorkspaces(userId)}, nil
+}
+
+func (s *chattingServer) AddWorkspaceMember(ctx context.Context, req *pb.WorkspaceMemberRequest) (*pb.Empty, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.AddMember(req.WorkspaceId, userId, req.UserId, req.Admin); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (s *chattingServer) RemoveWorkspaceMember(ctx context.Context, req *pb.WorkspaceMemberRequest) (*pb.Empty, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rooms, err := s.RemoveMember(req.WorkspaceId, userId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// the user may no longer use the rooms of the workspace
+	s.EndRoomWatchers(req.WorkspaceId, req.UserId)
+	for _, room := range rooms {
+		room.mu.Lock()
+		kicked := room.Kick(req.UserId, status.Errorf(codes.PermissionDenied, "removed from workspace %v", req.WorkspaceId))
+		room.mu.Unlock()
+
+		if kicked {
+			s.PublishEvent(room, pb.MessageKind_MESSAGE_KIND_LEAVE, req.UserId, s.nameOf(req.UserId)+" left")
+			s.BroadcastRoomEvent(pb.RoomEventType_ROOM_EVENT_MEMBER_COUNT_CHANGED, room)
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *chattingServer) GetChatRoom(ctx context.Context, req *pb.GetChatRoomRequest) (*pb.GetChatRoomResponse, error) {
+	userId, err := s.GetOptionalUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return PageRooms(s.VisibleRooms(workspaceId, userId), req)
+}
+
+func (s *chattingServer) WatchRooms(_ *pb.Empty, stream pb.Chatting_WatchRoomsServer) error {
+	ctx := stream.Context()
+	userId, err := s.GetOptionalUserId(&ctx)
+	if err != nil {
+		return err
+	}
+
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	w, rooms := s.WatchRoomEvents(workspaceId, userId)
+	defer s.UnwatchRoomEvents(w)
+
+	snapshot := &pb.RoomEvent{
+		Type:  pb.RoomEventType_ROOM_EVENT_SNAPSHOT,
+		Rooms: rooms,
+	}
+	if err := stream.Send(snapshot); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case event := <-w.events:
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case <-w.lagged:
+			return status.Error(codes.ResourceExhausted, "too many room events pending; watch again")
+		case <-w.removed:
+			return status.Errorf(codes.PermissionDenied, "removed from workspace %v", workspaceId)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (s *chattingServer) GetRoomByName(ctx context.Context, req *pb.GetRoomByNameRequest) (*pb.Room, error) {
+	userId, err := s.GetOptionalUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	slug := Slugify(req.Name)
+	room, err := s.FindRoomBySlug(workspaceId, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	room.mu.Lock()
+	visible := room.CanView(userId)
+	room.mu.Unlock()
+	if !visible {
+		return nil, status.Errorf(codes.NotFound, "room #%v not found", slug)
+	}
+
+	return room.ToProto(), nil
+}
+
+func (s *chattingServer) CreateRoom(ctx context.Context, req *pb.CreateRoomRequest) (*pb.Room, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.MaxMembers < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max members can not be negative")
+	}
+	if req.SlowModeSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "slow mode can not be negative")
+	}
+
+	slug := Slugify(req.RoomName)
+	if req.Slug != "" {
+		slug = Slugify(req.Slug)
+	}
+	if slug == "" {
+		return nil, status.Error(codes.InvalidArgument, "room name needs a letter or digit")
+	}
+
+	room := NewRoom(req.RoomName, userId)
+	room.Slug = slug
+	room.Topic = req.Topic
+	room.Description = req.Description
+	room.Visibility = req.Visibility
+	room.MaxMembers = req.MaxMembers
+	room.SlowMode = time.Duration(req.SlowModeSeconds) * time.Second
+	room.Announcement = req.Announcement
+	room.WorkspaceId = workspaceId
+
+	if _, err := s.CreateRoomId(room); err != nil {
+		return nil, err
+	}
+
+	s.BroadcastRoomEvent(pb.RoomEventType_ROOM_EVENT_CREATED, room)
+
+	return room.ToProto(), nil
+}
+
+func (s *chattingServer) RemoveRoom(ctx context.Context, req *pb.RemoveRoomRequest) (*pb.Empty, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := s.FindRoom(workspaceId, req.RoomId)
+	if err != nil {
+		return nil, err
+	}
+
+	room.mu.Lock()
+	err = room.Authorize(userId, PermRemoveRoom)
+	room.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// of concurrent removals only the first closes the room
+	if !s.RemoveRoomId(req.RoomId, req.Archive) {
+		return nil, removedError(req.RoomId)
+	}
+
+	s.PublishEvent(room, pb.MessageKind_MESSAGE_KIND_SYSTEM, userId, fmt.Sprintf("room %v is being removed", req.RoomId))
+
+	room.mu.Lock()
+	room.Close(removedError(req.RoomId))
+	room.mu.Unlock()
+
+	s.BroadcastRoomEvent(pb.RoomEventType_ROOM_EVENT_REMOVED, room)
+
+	return nil, nil
+}
+
+func (s *chattingServer) UpdateRoom(ctx context.Context, req *pb.UpdateRoomRequest) (*pb.Room, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := s.FindRoom(workspaceId, req.RoomId)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.RoomName != nil && *req.RoomName == "" {
+		return nil, status.Error(codes.InvalidArgument, "room name can not be empty")
+	}
+	if req.MaxMembers != nil && *req.MaxMembers < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max members can not be negative")
+	}
+	if req.SlowModeSeconds != nil && *req.SlowModeSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "slow mode can not be negative")
+	}
+
+	// moderators may only rename and describe the room
+	perm := PermRenameRoom
+	if req.Visibility != nil || req.MaxMembers != nil || req.SlowModeSeconds != nil || req.Announcement != nil {
+		perm = PermConfigureRoom
+	}
+
+	room.mu.Lock()
+	if err := room.Authorize(userId, perm); err != nil {
+		room.mu.Unlock()
+		return nil, err
+	}
+	if req.RoomName != nil {
+		room.RoomName = *req.RoomName
+	}
+	if req.Topic != nil {
+		room.Topic = *req.Topic
+	}
+	if req.Description != nil {
+		room.Description = *req.Description
+	}
+	if req.Visibility != nil {
+		room.Visibility = *req.Visibility
+	}
+	if req.MaxMembers != nil {
+		room.MaxMembers = *req.MaxMembers
+		room.admitWaiters()
+	}
+	policy := room.policyText()
+	if req.SlowModeSeconds != nil {
+		room.SlowMode = time.Duration(*req.SlowModeSeconds) * time.Second
+	}
+	if req.Announcement != nil {
+		room.Announcement = *req.Announcement
+	}
+	policyChanged := room.policyText() != policy
+	policy = room.policyText()
+	room.mu.Unlock()
+
+	if policyChanged {
+		s.PublishEvent(room, pb.MessageKind_MESSAGE_KIND_SYSTEM, userId, policy)
+	}
+
+	s.BroadcastRoomEvent(pb.RoomEventType_ROOM_EVENT_UPDATED, room)
+
+	return room.ToProto(), nil
+}
+
+func (s *chattingServer) ArchiveRoom(ctx context.Context, req *pb.RoomRequest) (*pb.Room, error) {
+	return s.setArchived(ctx, req.RoomId, true)
+}
+
+func (s *chattingServer) UnarchiveRoom(ctx context.Context, req *pb.RoomRequest) (*pb.Room, error) {
+	return s.setArchived(ctx, req.RoomId, false)
+}
+
+func (s *chattingServer) setArchived(ctx context.Context, roomId int64, archived bool) (*pb.Room, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := s.FindRoom(workspaceId, roomId)
+	if err != nil {
+		return nil, err
+	}
+
+	room.mu.Lock()
+	if err := room.Authorize(userId, PermArchive); err != nil {
+		room.mu.Unlock()
+		return nil, err
+	}
+	changed := room.Archived != archived
+	room.Archived = archived
+	room.history.keepAll = archived
+	room.mu.Unlock()
+
+	if changed {
+		text := fmt.Sprintf("room %v was archived and is now read-only", roomId)
+		if !archived {
+			text = fmt.Sprintf("room %v was restored", roomId)
+		}
+		s.PublishEvent(room, pb.MessageKind_MESSAGE_KIND_SYSTEM, userId, text)
+		s.BroadcastRoomEvent(pb.RoomEventType_ROOM_EVENT_UPDATED, room)
+	}
+
+	return room.ToProto(), nil
+}
+
+func (s *chattingServer) GrantRole(ctx context.Context, req *pb.GrantRoleRequest) (*pb.Empty, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := pb.Role_name[int32(req.Role)]; !ok || req.Role == pb.Role_ROLE_UNSPECIFIED {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid role: %v", req.Role)
+	}
+
+	if !s.UserExists(req.UserId) {
+		return nil, status.Errorf(codes.NotFound, "user %v not found", req.UserId)
+	}
+
+	if err := s.CheckWorkspace(workspaceId, req.UserId); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "user %v is not a member of workspace %v", req.UserId, workspaceId)
+	}
+
+	room, err := s.FindRoom(workspaceId, req.RoomId)
+	if err != nil {
+		return nil, err
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if err := room.Authorize(userId, PermManageRoles); err != nil {
+		return nil, err
+	}
+
+	if room.RoleOf(req.UserId) == pb.Role_ROLE_OWNER && req.Role != pb.Role_ROLE_OWNER && room.countRole(pb.Role_ROLE_OWNER) == 1 {
+		return nil, status.Error(codes.FailedPrecondition, "room must keep at least one owner")
+	}
+
+	room.Roles[req.UserId] = req.Role
+
+	return nil, nil
+}
+
+func (s *chattingServer) RevokeRole(ctx context.Context, req *pb.RevokeRoleRequest) (*pb.Empty, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := s.FindRoom(workspaceId, req.RoomId)
+	if err != nil {
+		return nil, err
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if err := room.Authorize(userId, PermManageRoles); err != nil {
+		return nil, err
+	}
+
+	role := room.RoleOf(req.UserId)
+	if role == pb.Role_ROLE_UNSPECIFIED {
+		return nil, status.Errorf(codes.NotFound, "user %v is not a member of room %v", req.UserId, req.RoomId)
+	}
+
+	if role == pb.Role_ROLE_OWNER && room.countRole(pb.Role_ROLE_OWNER) == 1 {
+		return nil, status.Error(codes.FailedPrecondition, "room must keep at least one owner")
+	}
+
+	room.Roles[req.UserId] = pb.Role_ROLE_MEMBER
+
+	return nil, nil
+}
+
+func (s *chattingServer) CreateInvite(ctx context.Context, req *pb.CreateInviteRequest) (*pb.Invite, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	log.Default().Printf("user %v login\n", userId)
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := s.FindRoom(workspaceId, req.RoomId)
+	if err != nil {
+		return nil, err
+	}
+
+	room.mu.Lock()
+	err = room.Authorize(userId, PermInvite)
+	room.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	invite := s.CreateInviteCode(req.RoomId, userId, time.Duration(req.TtlSeconds)*time.Second, req.MaxUses)
 
-	return &pb.User{UserId: userId}, nil
+	return invite.ToProto(), nil
 }
 
-func (s *chattingServer) Logout(ctx context.Context, _ *pb.Empty) (*pb.Empty, error) {
+func (s *chattingServer) JoinByInvite(ctx context.Context, req *pb.JoinByInviteRequest) (*pb.Room, error) {
 	userId, err := s.GetUserId(&ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	s.LogoutUser(userId)
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := s.RedeemInvite(workspaceId, userId, req.Code)
+	if err != nil {
+		return nil, err
+	}
 
-	return nil, nil
+	return room.ToProto(), nil
 }
 
-func (s *chattingServer) GetChatRoom(_ *pb.Empty, stream pb.Chatting_GetChatRoomServer) error {
-	for roomNumber, room := range s.Rooms {
-		room := pb.Room{
-			RoomId:   roomNumber,
-			RoomName: room.RoomName,
-		}
-		if err := stream.Send(&room); err != nil {
-			return err
-		}
+func (s *chattingServer) EnterChatRoom(ctx context.Context, room *pb.RoomRequest) (*pb.Empty, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, _, err := s.EnterRoom(workspaceId, userId, DeviceIdFrom(ctx), room.RoomId); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
 }
 
-func (s *chattingServer) CreateRoom(_ context.Context, room *pb.CreateRoomRequest) (*pb.Room, error) {
-	roomId, err := s.CreateRoomId(room.RoomName)
+func (s *chattingServer) ExitChatRoom(ctx context.Context, _ *pb.Empty) (*pb.Empty, error) {
+	userId, err := s.GetUserId(&ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.Room{RoomId: roomId, RoomName: room.RoomName}, nil
-}
+	roomId, err := s.GetRoomId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return nil, err
+	}
 
-func (s *chattingServer) RemoveRoom(_ context.Context, room *pb.RemoveRoomRequest) (*pb.Empty, error) {
-	s.RemoveRoomId(room.RoomId)
+	if err := s.ExitRoom(workspaceId, userId, DeviceIdFrom(ctx), roomId); err != nil {
+		return nil, err
+	}
 
 	return nil, nil
 }
 
-func (s *chattingServer) EnterChatRoom(ctx context.Context, room *pb.RoomRequest) (*pb.Empty, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return nil, status.Error(codes.Unauthenticated, "no metadata")
+func (s *chattingServer) JoinWaitlist(req *pb.RoomRequest, stream pb.Chatting_JoinWaitlistServer) error {
+	ctx := stream.Context()
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return err
 	}
 
-	userIdStrs := md.Get("user_id")
-	fmt.Println(userIdStrs)
-	userId64, err := strconv.ParseInt(userIdStrs[0], 10, 32)
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid user-id: %v", err)
+		return err
 	}
 
-	userId := int32(userId64)
+	room, err := s.FindRoom(workspaceId, req.RoomId)
+	if err != nil {
+		return err
+	}
 
-	targetRoom, ok := s.Rooms[room.RoomId]
-	if !ok {
-		return nil, errors.New("no room exist")
+	room.mu.Lock()
+	if room.IsClosed() {
+		room.mu.Unlock()
+		return removedError(req.RoomId)
+	}
+	if room.IsBanned(userId) {
+		room.mu.Unlock()
+		return bannedError(req.RoomId)
+	}
+	if !room.CanView(userId) {
+		room.mu.Unlock()
+		return status.Errorf(codes.PermissionDenied, "room %v is private; join it with an invite", req.RoomId)
+	}
+	if _, ok := room.Users[userId]; ok {
+		room.mu.Unlock()
+		return stream.Send(&pb.WaitlistEvent{RoomId: req.RoomId, Admitted: true})
+	}
+	w := room.enqueue(userId)
+	room.admitWaiters()
+	room.mu.Unlock()
+
+	defer func() {
+		room.mu.Lock()
+		room.dequeue(w)
+		room.mu.Unlock()
+	}()
+
+	for {
+		room.mu.Lock()
+		event := &pb.WaitlistEvent{
+			RoomId:   req.RoomId,
+			Position: room.position(w),
+			Admitted: w.admitted,
+		}
+		room.mu.Unlock()
+
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+
+		if event.Admitted {
+			// hold the slot until the user entered or gave up
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-room.closed:
+				return removedError(req.RoomId)
+			}
+		}
+
+		select {
+		case <-w.notify:
+		case <-ctx.Done():
+			return nil
+		case <-room.closed:
+			return removedError(req.RoomId)
+		}
+	}
+}
+
+func (s *chattingServer) ListRoomMembers(ctx context.Context, req *pb.RoomRequest) (*pb.ListRoomMembersResponse, error) {
+	userId, err := s.GetOptionalUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return nil, err
 	}
 
-	targetRoom.Users[userId] = &UserInRoom{}
+	room, err := s.FindRoom(workspaceId, req.RoomId)
+	if err != nil {
+		return nil, err
+	}
 
-	fmt.Println(targetRoom)
+	room.mu.Lock()
+	visible := room.CanView(userId)
+	room.mu.Unlock()
+	if !visible {
+		return nil, status.Errorf(codes.PermissionDenied, "room %v is private", req.RoomId)
+	}
 
-	return nil, nil
+	members := room.Members()
+	for _, member := range members {
+		member.DisplayName = s.DisplayName(member.UserId)
+		member.Presence = s.PresenceOf(member.UserId)
+	}
+
+	return &pb.ListRoomMembersResponse{Members: members}, nil
 }
 
-func (s *chattingServer) ExitChatRoom(ctx context.Context, _ *pb.Empty) (*pb.Empty, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return nil, status.Error(codes.Unauthenticated, "no metadata")
+func (s *chattingServer) KickUser(ctx context.Context, req *pb.ModerationRequest) (*pb.Empty, error) {
+	kicked := false
+	room, err := s.moderate(ctx, req.RoomId, req.UserId, PermKick, func(room *Room) {
+		kicked = room.Kick(req.UserId, kickedError(req.RoomId, req.Reason))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if kicked {
+		s.PublishEvent(room, pb.MessageKind_MESSAGE_KIND_LEAVE, req.UserId, s.nameOf(req.UserId)+" was kicked")
+		s.BroadcastRoomEvent(pb.RoomEventType_ROOM_EVENT_MEMBER_COUNT_CHANGED, room)
 	}
 
-	roomIdStrs := md.Get("room_id")
-	roomId64, err := strconv.ParseInt(roomIdStrs[0], 10, 32)
+	return nil, nil
+}
+
+func (s *chattingServer) BanUser(ctx context.Context, req *pb.ModerationRequest) (*pb.Empty, error) {
+	kicked := false
+	room, err := s.moderate(ctx, req.RoomId, req.UserId, PermBan, func(room *Room) {
+		room.Banned[req.UserId] = struct{}{}
+		delete(room.Roles, req.UserId)
+		kicked = room.Kick(req.UserId, bannedError(req.RoomId))
+	})
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid user-id: %v", err)
+		return nil, err
+	}
+
+	if kicked {
+		s.PublishEvent(room, pb.MessageKind_MESSAGE_KIND_LEAVE, req.UserId, s.nameOf(req.UserId)+" was banned")
+		s.BroadcastRoomEvent(pb.RoomEventType_ROOM_EVENT_MEMBER_COUNT_CHANGED, room)
 	}
 
-	roomId := int32(roomId64)
+	return nil, nil
+}
 
-	userIdStrs := md.Get("user_id")
-	userId64, err := strconv.ParseInt(userIdStrs[0], 10, 32)
+func (s *chattingServer) UnbanUser(ctx context.Context, req *pb.ModerationRequest) (*pb.Empty, error) {
+	_, err := s.moderate(ctx, req.RoomId, req.UserId, PermBan, func(room *Room) {
+		delete(room.Banned, req.UserId)
+	})
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid user-id: %v", err)
+		return nil, err
 	}
 
-	userId := int32(userId64)
+	return nil, nil
+}
 
-	targetRoom, ok := s.Rooms[roomId]
-	if !ok {
-		return nil, errors.New("no room exist")
+func (s *chattingServer) MuteUser(ctx context.Context, req *pb.MuteUserRequest) (*pb.Empty, error) {
+	if req.DurationSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "duration can not be negative")
+	}
+
+	duration := time.Duration(req.DurationSeconds) * time.Second
+
+	var notify []*UserInRoom
+	room, err := s.moderate(ctx, req.RoomId, req.UserId, PermMute, func(room *Room) {
+		if duration == 0 {
+			delete(room.Muted, req.UserId)
+		} else {
+			room.Muted[req.UserId] = time.Now().Add(duration)
+		}
+		notify = room.Devices(req.UserId)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	delete(targetRoom.Users, userId)
+	for _, notify := range notify {
+		if duration == 0 {
+			room.Notify(notify, "you are no longer muted")
+		} else {
+			room.Notify(notify, fmt.Sprintf("you are muted for %v", duration))
+		}
+	}
 
 	return nil, nil
 }
 
 func (s *chattingServer) Chatting(stream pb.Chatting_ChattingServer) error {
-	waitc := make(chan struct{})
-
-	md, ok := metadata.FromIncomingContext(stream.Context())
-	if !ok {
-		return status.Error(codes.Unauthenticated, "no metadata")
+	ctx := stream.Context()
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return err
 	}
 
-	roomIdStrs := md.Get("room_id")
-	roomId64, err := strconv.ParseInt(roomIdStrs[0], 10, 32)
+	roomId, err := s.GetRoomId(&ctx)
 	if err != nil {
-		return status.Errorf(codes.InvalidArgument, "invalid user-id: %v", err)
+		return err
 	}
 
-	roomId := int32(roomId64)
-	room, ok := s.Rooms[roomId]
-	if !ok {
-		return errors.New("room not found")
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return err
 	}
 
-	userIdStrs := md.Get("user_id")
-	userId64, err := strconv.ParseInt(userIdStrs[0], 10, 32)
+	room, err := s.FindRoom(workspaceId, roomId)
 	if err != nil {
-		return status.Errorf(codes.InvalidArgument, "invalid user-id: %v", err)
+		return err
 	}
 
-	userId := int32(userId64)
-	user, ok := room.Users[userId]
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	deviceId := DeviceIdFrom(ctx)
+	room.mu.Lock()
+	user, ok := room.Users[userId][deviceId]
+	if ok {
+		user.cancel = cancel
+	}
+	room.mu.Unlock()
 	if !ok {
-		return errors.New("user not found")
+		return status.Errorf(codes.FailedPrecondition, "enter room %v before chatting in it", roomId)
 	}
 
+	// the device leaves the room together with its stream, so that a
+	// client gone without ExitChatRoom does not keep its slot
+	defer func() {
+		room.mu.Lock()
+		current := room.InRoom(userId, user)
+		room.mu.Unlock()
+		if current {
+			s.ExitRoom(workspaceId, userId, deviceId, roomId)
+		}
+	}()
+
+	errc := make(chan error, 1)
+
 	go func() {
 		for {
 			in, err := stream.Recv()
 			if err == io.EOF {
-				close(waitc)
+				errc <- nil
 				return
 			}
 			if err != nil {
-				log.Fatalf("client.Chatting failed: %v", err)
-				close(waitc)
+				errc <- err
 				return
 			}
 
-			fmt.Println(in)
+			s.Receive(room, user, userId, in)
+		}
+	}()
 
-			room.mu.Lock()
-			for id, buffer := range room.Users {
-				if userId != id {
-					buffer.Buffer = append(buffer.Buffer, in)
+	for {
+		select {
+		case err := <-errc:
+			return err
+		case <-ctx.Done():
+			// deliver what was queued before the stream was ended, such
+			// as the notice that the room is being removed
+			for _, msg := range room.Drain(user) {
+				if err := stream.Send(msg); err != nil {
+					break
 				}
-				fmt.Println(buffer)
 			}
-			room.mu.Unlock()
+			return context.Cause(ctx)
+		case <-user.signal:
+		}
+
+		for _, msg := range room.Drain(user) {
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// errLeftRoom ends the delivery of a room the session left on purpose.
+var errLeftRoom = errors.New("left room")
+
+func (s *chattingServer) Session(stream pb.Chatting_SessionServer) error {
+	ctx := stream.Context()
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return err
+	}
+
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	deviceId := DeviceIdFrom(ctx)
+
+	type sessionRoom struct {
+		room   *Room
+		user   *UserInRoom
+		cancel context.CancelCauseFunc
+		// done is closed once the session is no longer in the room
+		done <-chan struct{}
+	}
+
+	var sendMu sync.Mutex
+	send := func(roomId int64, msg *pb.Message) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+
+		return stream.Send(&pb.SessionEvent{RoomId: roomId, Message: msg})
+	}
+	notice := func(roomId int64, text string) {
+		send(roomId, &pb.Message{Msg: text, Kind: pb.MessageKind_MESSAGE_KIND_NOTICE})
+	}
+
+	// deliver pumps the messages of one room into the stream until the
+	// session leaves the room or is removed from it
+	deliver := func(roomId int64, joined *sessionRoom, roomCtx context.Context) {
+		for {
+			select {
+			case <-roomCtx.Done():
+				for _, msg := range joined.room.Drain(joined.user) {
+					send(roomId, msg)
+				}
+				if cause := context.Cause(roomCtx); cause != errLeftRoom && ctx.Err() == nil {
+					notice(roomId, status.Convert(cause).Message())
+				}
+				return
+			case <-joined.user.signal:
+			}
+
+			for _, msg := range joined.room.Drain(joined.user) {
+				if err := send(roomId, msg); err != nil {
+					return
+				}
+			}
+		}
+	}
 
-			fmt.Println(room)
+	rooms := map[int64]*sessionRoom{}
+	// delivering counts the deliver goroutines, which send on the stream and
+	// so have to be done before the handler returns
+	var delivering sync.WaitGroup
+	defer func() {
+		for roomId, joined := range rooms {
+			select {
+			case <-joined.done:
+				// already out of the room
+			default:
+				joined.cancel(errLeftRoom)
+				s.ExitRoom(workspaceId, userId, deviceId, roomId)
+			}
+		}
+		delivering.Wait()
+	}()
+
+	// receive in the background so that the session also ends once the
+	// stream context does, such as when its login session is revoked
+	requests := make(chan *pb.SessionRequest)
+	errc := make(chan error, 1)
+	go func() {
+		for {
+			in, err := stream.Recv()
+			if err != nil {
+				errc <- err
+				return
+			}
+
+			select {
+			case requests <- in:
+			case <-ctx.Done():
+				return
+			}
 		}
 	}()
 
 	for {
-		room.mu.Lock()
-		for _, buffer := range user.Buffer {
-			if err := stream.Send(buffer); err != nil {
-				return err
+		var in *pb.SessionRequest
+		select {
+		case in = <-requests:
+		case err := <-errc:
+			if err == io.EOF {
+				return nil
 			}
+			return err
+		case <-ctx.Done():
+			return context.Cause(ctx)
 		}
-		user.Buffer = []*pb.Message{}
-		room.mu.Unlock()
+
+		switch in.Action {
+		case pb.SessionAction_SESSION_ACTION_JOIN:
+			if joined, ok := rooms[in.RoomId]; ok {
+				select {
+				case <-joined.done:
+					// kicked or removed, so join again
+				default:
+					continue
+				}
+			}
+
+			room, user, err := s.EnterRoom(workspaceId, userId, deviceId, in.RoomId)
+			if err != nil {
+				notice(in.RoomId, status.Convert(err).Message())
+				continue
+			}
+
+			roomCtx, cancel := context.WithCancelCause(ctx)
+			room.mu.Lock()
+			user.cancel = cancel
+			room.mu.Unlock()
+
+			joined := &sessionRoom{room: room, user: user, cancel: cancel, done: roomCtx.Done()}
+			rooms[in.RoomId] = joined
+			delivering.Add(1)
+			go func(roomId int64) {
+				defer delivering.Done()
+				deliver(roomId, joined, roomCtx)
+			}(in.RoomId)
+
+		case pb.SessionAction_SESSION_ACTION_LEAVE:
+			joined, ok := rooms[in.RoomId]
+			if !ok {
+				continue
+			}
+
+			delete(rooms, in.RoomId)
+			joined.cancel(errLeftRoom)
+			s.ExitRoom(workspaceId, userId, deviceId, in.RoomId)
+
+		case pb.SessionAction_SESSION_ACTION_SEND:
+			joined, ok := rooms[in.RoomId]
+			if ok {
+				select {
+				case <-joined.done:
+					ok = false
+				default:
+				}
+			}
+			if !ok {
+				notice(in.RoomId, fmt.Sprintf("join room %v before sending to it", in.RoomId))
+				continue
+			}
+			if in.Message == nil {
+				continue
+			}
+
+			s.Receive(joined.room, joined.user, userId, in.Message)
+		}
+	}
+}
+
+func (s *chattingServer) GetHistory(req *pb.HistoryRequest, stream pb.Chatting_GetHistoryServer) error {
+	ctx := stream.Context()
+	userId, err := s.GetOptionalUserId(&ctx)
+	if err != nil {
+		return err
+	}
+
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	room, err := s.FindArchivedRoom(workspaceId, req.RoomId)
+	if err != nil {
+		return err
 	}
 
-	// <-waitc
+	room.mu.Lock()
+	visible := room.CanView(userId)
+	room.mu.Unlock()
+	if !visible {
+		return status.Errorf(codes.PermissionDenied, "room %v is private", req.RoomId)
+	}
+
+	messages, err := room.HistoryRange(req.FromSeq, req.ToSeq)
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range messages {
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *chattingServer) SearchHistory(req *pb.SearchHistoryRequest, stream pb.Chatting_SearchHistoryServer) error {
+	ctx := stream.Context()
+	userId, err := s.GetOptionalUserId(&ctx)
+	if err != nil {
+		return err
+	}
+
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	if req.Query == "" {
+		return status.Error(codes.InvalidArgument, "query can not be empty")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 100
+	}
+
+	room, err := s.FindArchivedRoom(workspaceId, req.RoomId)
+	if err != nil {
+		return err
+	}
+
+	room.mu.Lock()
+	visible := room.CanView(userId)
+	room.mu.Unlock()
+	if !visible {
+		return status.Errorf(codes.PermissionDenied, "room %v is private", req.RoomId)
+	}
+
+	for _, msg := range room.Search(req.Query, limit) {
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,156 @@
+package chattingserver
+
+import (
+	"context"
+	pb "grpc-example/chatting"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// sessionStream is the server side of a Session stream whose requests are
+// sent on in and whose events arrive on out. Closing in ends the stream.
+type sessionStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	in  chan *pb.SessionRequest
+	out chan *pb.SessionEvent
+}
+
+func (s *sessionStream) Context() context.Context { return s.ctx }
+
+func (s *sessionStream) Send(event *pb.SessionEvent) error {
+	s.out <- event
+	return nil
+}
+
+func (s *sessionStream) Recv() (*pb.SessionRequest, error) {
+	select {
+	case req, ok := <-s.in:
+		if !ok {
+			return nil, io.EOF
+		}
+		return req, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+// openSession runs a Session for userId from deviceId. The returned channel
+// reports the result of the handler.
+func openSession(c *chattingServer, userId int64, deviceId int64) (*sessionStream, <-chan error) {
+	stream := &sessionStream{
+		ctx: asUser(userId, deviceId),
+		in:  make(chan *pb.SessionRequest),
+		out: make(chan *pb.SessionEvent, 100),
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- c.Session(stream) }()
+
+	return stream, done
+}
+
+// awaitEvents waits for an event on the stream matching each of wants, in
+// any order, skipping the others.
+func awaitEvents(t *testing.T, stream *sessionStream, wants ...func(event *pb.SessionEvent) bool) {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for len(wants) > 0 {
+		select {
+		case event := <-stream.out:
+			for i, want := range wants {
+				if want(event) {
+					wants = slices.Delete(wants, i, i+1)
+					break
+				}
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for %v session events", len(wants))
+		}
+	}
+}
+
+func chatIn(roomId int64, text string) func(event *pb.SessionEvent) bool {
+	return func(event *pb.SessionEvent) bool {
+		return event.RoomId == roomId && event.Message.Kind == pb.MessageKind_MESSAGE_KIND_CHAT && event.Message.Msg == text
+	}
+}
+
+func noticeIn(roomId int64) func(event *pb.SessionEvent) bool {
+	return func(event *pb.SessionEvent) bool {
+		return event.RoomId == roomId && event.Message.Kind == pb.MessageKind_MESSAGE_KIND_NOTICE
+	}
+}
+
+func TestSession(t *testing.T) {
+	c := newTestServer(t)
+	first := newTestRoom(t, c, 1)
+	second := NewRoom("other", 1)
+	second.Slug = "other"
+	second.WorkspaceId = defaultWorkspaceId
+	if _, err := c.CreateRoomId(second); err != nil {
+		t.Fatal(err)
+	}
+	bob := enter(t, c, first, 2, 20)
+
+	stream, done := openSession(c, 1, 10)
+	stream.in <- &pb.SessionRequest{RoomId: first.RoomId, Action: pb.SessionAction_SESSION_ACTION_JOIN}
+	stream.in <- &pb.SessionRequest{RoomId: second.RoomId, Action: pb.SessionAction_SESSION_ACTION_JOIN}
+
+	// one stream carries the messages of both rooms
+	stream.in <- &pb.SessionRequest{RoomId: first.RoomId, Action: pb.SessionAction_SESSION_ACTION_SEND, Message: &pb.Message{Msg: "hi"}}
+	stream.in <- &pb.SessionRequest{RoomId: second.RoomId, Action: pb.SessionAction_SESSION_ACTION_SEND, Message: &pb.Message{Msg: "hello"}}
+	awaitEvents(t, stream, chatIn(first.RoomId, "hi"), chatIn(second.RoomId, "hello"))
+
+	c.Receive(first, bob, 2, &pb.Message{Msg: "hey"})
+	awaitEvents(t, stream, chatIn(first.RoomId, "hey"))
+
+	// sending to a room left is refused
+	stream.in <- &pb.SessionRequest{RoomId: second.RoomId, Action: pb.SessionAction_SESSION_ACTION_LEAVE}
+	stream.in <- &pb.SessionRequest{RoomId: second.RoomId, Action: pb.SessionAction_SESSION_ACTION_SEND, Message: &pb.Message{Msg: "hello"}}
+	awaitEvents(t, stream, noticeIn(second.RoomId))
+
+	close(stream.in)
+	if err := <-done; err != nil {
+		t.Fatalf("Session() error = %v", err)
+	}
+
+	// ending the stream exits the rooms still joined
+	first.mu.Lock()
+	_, inRoom := first.Users[1]
+	first.mu.Unlock()
+	if inRoom {
+		t.Error("user is still in the room after the session ended")
+	}
+}
+
+func TestSessionKicked(t *testing.T) {
+	c := newTestServer(t)
+	room := newTestRoom(t, c, 1)
+
+	stream, done := openSession(c, 2, 20)
+	stream.in <- &pb.SessionRequest{RoomId: room.RoomId, Action: pb.SessionAction_SESSION_ACTION_JOIN}
+	awaitEvents(t, stream, func(event *pb.SessionEvent) bool {
+		return event.Message.Kind == pb.MessageKind_MESSAGE_KIND_JOIN
+	})
+
+	if _, err := c.KickUser(asUser(1, 10), &pb.ModerationRequest{RoomId: room.RoomId, UserId: 2}); err != nil {
+		t.Fatal(err)
+	}
+	awaitEvents(t, stream, noticeIn(room.RoomId))
+
+	// the session outlives the kick and may join again
+	stream.in <- &pb.SessionRequest{RoomId: room.RoomId, Action: pb.SessionAction_SESSION_ACTION_JOIN}
+	stream.in <- &pb.SessionRequest{RoomId: room.RoomId, Action: pb.SessionAction_SESSION_ACTION_SEND, Message: &pb.Message{Msg: "back"}}
+	awaitEvents(t, stream, chatIn(room.RoomId, "back"))
+
+	close(stream.in)
+	if err := <-done; err != nil {
+		t.Fatalf("Session() error = %v", err)
+	}
+}
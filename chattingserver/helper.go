@@ -2,88 +2,182 @@ package chattingserver
 
 import (
 	"context"
-	"errors"
-	"math/rand"
-	"strconv"
+	pb "grpc-example/chatting"
 
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
-func (c *chattingServer) GetUserId(ctx *context.Context) (int32, error) {
-	md, ok := metadata.FromIncomingContext(*ctx)
+// GetUserId returns the user authenticated by the token of the call.
+func (c *chattingServer) GetUserId(ctx *context.Context) (int64, error) {
+	userId, ok := UserIdFrom(*ctx)
 	if !ok {
-		return 0, status.Error(codes.Unauthenticated, "no metadata")
+		return 0, status.Error(codes.Unauthenticated, "log in first")
 	}
 
-	userIdStrs := md.Get("user_id")
-	userId64, err := strconv.ParseInt(userIdStrs[0], 10, 32)
-	if err != nil {
-		return 0, status.Errorf(codes.InvalidArgument, "invalid user-id: %v", err)
-	}
-
-	userId := int32(userId64)
+	return userId, nil
+}
 
+// GetOptionalUserId is GetUserId for calls that anonymous users may make too;
+// it returns 0 when no token was sent.
+func (c *chattingServer) GetOptionalUserId(ctx *context.Context) (int64, error) {
+	userId, _ := UserIdFrom(*ctx)
 	return userId, nil
 }
 
-func (c *chattingServer) GetRoomId(ctx *context.Context) (int32, error) {
-	md, ok := metadata.FromIncomingContext(*ctx)
+// GetRoomId returns the room named by the room_id metadata.
+func (c *chattingServer) GetRoomId(ctx *context.Context) (int64, error) {
+	roomId, ok := RoomIdFrom(*ctx)
 	if !ok {
-		return 0, status.Error(codes.Unauthenticated, "no metadata")
+		return 0, status.Error(codes.InvalidArgument, "room_id metadata required")
 	}
 
-	roomIdStrs := md.Get("room_id")
-	roomId64, err := strconv.ParseInt(roomIdStrs[0], 10, 32)
-	if err != nil {
-		return 0, status.Errorf(codes.InvalidArgument, "invalid user-id: %v", err)
+	return roomId, nil
+}
+
+// GetWorkspaceId returns the workspace named by the workspace_id metadata,
+// or the default workspace when none is named.
+func (c *chattingServer) GetWorkspaceId(ctx *context.Context) int64 {
+	return WorkspaceIdFrom(*ctx)
+}
+
+// GetWorkspace returns the workspace of the call after checking that userId
+// is a member of it.
+func (c *chattingServer) GetWorkspace(ctx *context.Context, userId int64) (int64, error) {
+	workspaceId := c.GetWorkspaceId(ctx)
+	if err := c.CheckWorkspace(workspaceId, userId); err != nil {
+		return 0, err
 	}
 
-	roomId := int32(roomId64)
+	return workspaceId, nil
+}
 
-	return roomId, nil
+// UserExists reports whether userId is a registered account.
+func (c *chattingServer) UserExists(userId int64) bool {
+	_, err := c.users.FindById(userId)
+	return err == nil
 }
 
-func (c *chattingServer) LoginUser() (int32, error) {
-	for i := 0; i < 100; i++ {
-		tmp := rand.Int31()
-		_, ok := c.Users[tmp]
-		if !ok {
-			c.Users[tmp] = struct{}{}
-			return tmp, nil
+// CreateRoomId registers the room under a new id. It fails when another
+// room of the workspace already has the slug of the room.
+func (c *chattingServer) CreateRoomId(room *Room) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.slugTaken(room.WorkspaceId, room.Slug) {
+		return 0, status.Errorf(codes.AlreadyExists, "room #%v already exists", room.Slug)
+	}
+
+	room.RoomId = c.ids.NextID()
+	c.Rooms[room.RoomId] = room
+	return room.RoomId, nil
+}
+
+// RemoveRoomId drops the room and its invites. With archive set the room is
+// kept in Archives so that its history stays readable. It reports false when
+// the room was already removed.
+func (c *chattingServer) RemoveRoomId(roomNumber int64, archive bool) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	room, ok := c.Rooms[roomNumber]
+	if !ok {
+		return false
+	}
+
+	if archive {
+		room.mu.Lock()
+		room.history.keepAll = true
+		room.mu.Unlock()
+		c.Archives[roomNumber] = room
+	}
+	delete(c.Rooms, roomNumber)
+	c.forgetDedup(func(sender dedupSender) bool { return sender.roomId == roomNumber })
+
+	for code, invite := range c.Invites {
+		if invite.RoomId == roomNumber {
+			delete(c.Invites, code)
 		}
 	}
-	return 0, errors.New("can not make new user")
+
+	return true
 }
 
-func (c *chattingServer) LogoutUser(userId int32) {
-	delete(c.Users, userId)
+// FindArchivedRoom is FindRoom falling back to removed rooms whose history
+// was archived.
+func (c *chattingServer) FindArchivedRoom(workspaceId int64, roomId int64) (*Room, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if room, ok := c.Rooms[roomId]; ok && room.WorkspaceId == workspaceId {
+		return room, nil
+	}
+	if room, ok := c.Archives[roomId]; ok && room.WorkspaceId == workspaceId {
+		return room, nil
+	}
+
+	return nil, roomNotFound(roomId)
 }
 
-func (c *chattingServer) CreateRoomId(roomName string) (int32, error) {
-	for i := 0; i < 100; i++ {
-		tmp := rand.Int31()
-		_, ok := c.Users[tmp]
-		if !ok {
-			c.Rooms[tmp] = &Room{
-				RoomName: roomName,
-				Users:    map[int32]*UserInRoom{},
-			}
-			return tmp, nil
+// ListRooms returns the rooms of the workspace.
+func (c *chattingServer) ListRooms(workspaceId int64) []*Room {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var rooms []*Room
+	for _, room := range c.Rooms {
+		if room.WorkspaceId == workspaceId {
+			rooms = append(rooms, room)
 		}
 	}
-	return 0, errors.New("can not make new user")
+
+	return rooms
 }
 
-func (c *chattingServer) RemoveRoomId(roomNumber int32) {
-	delete(c.Rooms, roomNumber)
+// VisibleRooms returns the rooms of the workspace userId may see.
+func (c *chattingServer) VisibleRooms(workspaceId int64, userId int64) []*pb.Room {
+	var rooms []*pb.Room
+	for _, room := range c.ListRooms(workspaceId) {
+		room.mu.Lock()
+		visible := room.CanView(userId)
+		room.mu.Unlock()
+		if !visible {
+			continue
+		}
+
+		rooms = append(rooms, room.ToProto())
+	}
+
+	return rooms
+}
+
+func (c *chattingServer) NextMessageId() int64 {
+	return c.ids.NextID()
+}
+
+// PublishEvent publishes a server generated event about userId to the room.
+func (c *chattingServer) PublishEvent(room *Room, kind pb.MessageKind, userId int64, text string) {
+	room.Publish(&pb.Message{
+		Msg:         text,
+		UserId:      userId,
+		MessageId:   c.NextMessageId(),
+		Kind:        kind,
+		DisplayName: c.DisplayName(userId),
+	})
+}
+
+func roomNotFound(roomId int64) error {
+	return status.Errorf(codes.NotFound, "room %v not found", roomId)
 }
 
-func (c *chattingServer) FindRoom(roomId int32) (*Room, error) {
+// FindRoom returns the room if it belongs to the workspace.
+func (c *chattingServer) FindRoom(workspaceId int64, roomId int64) (*Room, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	room, ok := c.Rooms[roomId]
-	if !ok {
-		return nil, errors.New("room not found")
+	if !ok || room.WorkspaceId != workspaceId {
+		return nil, roomNotFound(roomId)
 	}
 
 	return room, nil
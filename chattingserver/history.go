@@ -0,0 +1,66 @@
+package chattingserver
+
+import (
+	pb "grpc-example/chatting"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// historySize is the number of recent messages a room in use keeps.
+const historySize = 1000
+
+// history keeps the messages of a room by their sequence number, which
+// starts at 1 and has no gaps. Only the latest historySize messages are kept,
+// unless keepAll is set.
+type history struct {
+	messages []*pb.Message
+	// first is the sequence number of messages[0].
+	first int64
+	// keepAll keeps every message from now on, such as for archived rooms
+	// whose history stays readable.
+	keepAll bool
+}
+
+func (h *history) add(msg *pb.Message) {
+	if len(h.messages) == 0 {
+		h.first = msg.Seq
+	}
+	h.messages = append(h.messages, msg)
+
+	if h.keepAll || len(h.messages) <= historySize {
+		return
+	}
+
+	drop := len(h.messages) - historySize
+	clear(h.messages[:drop])
+	h.messages = h.messages[drop:]
+	h.first += int64(drop)
+}
+
+// last returns the sequence number of the latest message kept.
+func (h *history) last() int64 {
+	return h.first + int64(len(h.messages)) - 1
+}
+
+// between returns the messages with fromSeq <= seq <= toSeq. A fromSeq of 0
+// starts at the oldest message kept; it fails with OutOfRange when the
+// messages from fromSeq on are no longer all kept.
+func (h *history) between(fromSeq, toSeq int64) ([]*pb.Message, error) {
+	if len(h.messages) == 0 {
+		return nil, nil
+	}
+
+	if fromSeq < 1 {
+		fromSeq = h.first
+	}
+	if fromSeq < h.first && fromSeq <= toSeq {
+		return nil, status.Errorf(codes.OutOfRange, "messages before seq %v are no longer kept", h.first)
+	}
+	toSeq = min(toSeq, h.last())
+	if fromSeq > toSeq {
+		return nil, nil
+	}
+
+	return append([]*pb.Message(nil), h.messages[fromSeq-h.first:toSeq-h.first+1]...), nil
+}
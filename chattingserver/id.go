@@ -0,0 +1,67 @@
+package chattingserver
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// IDGenerator hands out ids that never repeat across the nodes of a
+// deployment and sort by creation time.
+type IDGenerator interface {
+	NextID() int64
+}
+
+// Snowflake ids hold, from the top, 41 bits of milliseconds since
+// snowflakeEpoch, 10 bits of node id and 12 bits of sequence within the
+// millisecond.
+const (
+	snowflakeNodeBits = 10
+	snowflakeSeqBits  = 12
+
+	MaxNodeId = 1<<snowflakeNodeBits - 1
+)
+
+var snowflakeEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type snowflake struct {
+	node int64
+
+	mu     sync.Mutex
+	lastMs int64
+	seq    int64
+}
+
+// NewSnowflake returns an IDGenerator for the node. Every node of a
+// deployment needs its own node id between 0 and MaxNodeId.
+func NewSnowflake(node int64) (IDGenerator, error) {
+	if node < 0 || node > MaxNodeId {
+		return nil, fmt.Errorf("node id %v out of range 0..%v", node, MaxNodeId)
+	}
+
+	return &snowflake{node: node}, nil
+}
+
+func (s *snowflake) NextID() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ms := time.Since(snowflakeEpoch).Milliseconds()
+	if ms < s.lastMs {
+		// the clock went back; keep counting from the last id
+		ms = s.lastMs
+	}
+
+	if ms == s.lastMs {
+		s.seq = (s.seq + 1) & (1<<snowflakeSeqBits - 1)
+		if s.seq == 0 {
+			// the sequence ran out; borrow the next millisecond
+			ms++
+		}
+	} else {
+		s.seq = 0
+	}
+	s.lastMs = ms
+
+	return ms<<(snowflakeNodeBits+snowflakeSeqBits) | s.node<<snowflakeSeqBits | s.seq
+}
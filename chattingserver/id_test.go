@@ -0,0 +1,50 @@
+package chattingserver
+
+import "testing"
+
+func TestNewSnowflakeNodeRange(t *testing.T) {
+	for _, node := range []int64{-1, MaxNodeId + 1} {
+		if _, err := NewSnowflake(node); err == nil {
+			t.Errorf("NewSnowflake(%v) succeeded", node)
+		}
+	}
+	for _, node := range []int64{0, MaxNodeId} {
+		if _, err := NewSnowflake(node); err != nil {
+			t.Errorf("NewSnowflake(%v) error = %v", node, err)
+		}
+	}
+}
+
+func TestSnowflakeIncreases(t *testing.T) {
+	ids, err := NewSnowflake(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// more than fit into one millisecond
+	last := int64(0)
+	for i := 0; i < 3*(1<<snowflakeSeqBits); i++ {
+		id := ids.NextID()
+		if id <= last {
+			t.Fatalf("NextID() = %v after %v", id, last)
+		}
+		last = id
+
+		if node := id >> snowflakeSeqBits & MaxNodeId; node != 5 {
+			t.Fatalf("NextID() = %v carries node %v, want 5", id, node)
+		}
+	}
+}
+
+func TestSnowflakeClockBack(t *testing.T) {
+	s := &snowflake{node: 1}
+	first := s.NextID()
+
+	// pretend the clock went back an hour
+	s.lastMs += 3600 * 1000
+	ahead := s.lastMs
+
+	if id := s.NextID(); id <= first || id>>(snowflakeNodeBits+snowflakeSeqBits) != ahead {
+		t.Errorf("NextID() = %v, want the time of the last id %v", id, ahead)
+	}
+}
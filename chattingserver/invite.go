@@ -0,0 +1,107 @@
+package chattingserver
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	pb "grpc-example/chatting"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultInviteTTL     = 24 * time.Hour
+	defaultInviteMaxUses = 1
+)
+
+type Invite struct {
+	Code      string
+	RoomId    int64
+	CreatedBy int64
+	ExpiresAt time.Time
+	MaxUses   int32
+	Uses      int32
+}
+
+func (i *Invite) ToProto() *pb.Invite {
+	return &pb.Invite{
+		Code:      i.Code,
+		RoomId:    i.RoomId,
+		ExpiresAt: timestamppb.New(i.ExpiresAt),
+		MaxUses:   i.MaxUses,
+		Uses:      i.Uses,
+	}
+}
+
+func newInviteCode() string {
+	b := make([]byte, 10)
+	rand.Read(b)
+	return base32.StdEncoding.EncodeToString(b)
+}
+
+func (c *chattingServer) CreateInviteCode(roomId int64, createdBy int64, ttl time.Duration, maxUses int32) *Invite {
+	if ttl <= 0 {
+		ttl = defaultInviteTTL
+	}
+	if maxUses <= 0 {
+		maxUses = defaultInviteMaxUses
+	}
+
+	invite := &Invite{
+		Code:      newInviteCode(),
+		RoomId:    roomId,
+		CreatedBy: createdBy,
+		ExpiresAt: time.Now().Add(ttl),
+		MaxUses:   maxUses,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Invites[invite.Code] = invite
+	return invite
+}
+
+// RedeemInvite counts one use of the invite by userId, makes userId a member
+// of the room it is for and returns the room. Invites for rooms of other
+// workspaces are not found. Expired and used up invites are dropped. Users
+// banned from the room are refused without using the invite up.
+func (c *chattingServer) RedeemInvite(workspaceId int64, userId int64, code string) (*Room, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	invite, ok := c.Invites[code]
+	var room *Room
+	if ok {
+		room, ok = c.Rooms[invite.RoomId]
+		ok = ok && room.WorkspaceId == workspaceId
+	}
+	if !ok {
+		return nil, status.Error(codes.NotFound, "invite not found")
+	}
+
+	if time.Now().After(invite.ExpiresAt) {
+		delete(c.Invites, code)
+		return nil, status.Error(codes.NotFound, "invite expired")
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if room.IsBanned(userId) {
+		return nil, bannedError(invite.RoomId)
+	}
+
+	invite.Uses++
+	if invite.Uses >= invite.MaxUses {
+		delete(c.Invites, code)
+	}
+
+	if room.RoleOf(userId) == pb.Role_ROLE_UNSPECIFIED {
+		room.Roles[userId] = pb.Role_ROLE_MEMBER
+	}
+
+	return room, nil
+}
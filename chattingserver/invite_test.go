@@ -0,0 +1,40 @@
+package chattingserver
+
+import (
+	pb "grpc-example/chatting"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRedeemInviteBanned(t *testing.T) {
+	c := newTestServer(t)
+	room := newTestRoom(t, c, 1)
+	invite := c.CreateInviteCode(room.RoomId, 1, time.Hour, 1)
+
+	room.mu.Lock()
+	room.Banned[2] = struct{}{}
+	room.mu.Unlock()
+
+	if _, err := c.RedeemInvite(defaultWorkspaceId, 2, invite.Code); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("RedeemInvite() by a banned user error = %v, want PermissionDenied", err)
+	}
+
+	// the refusal did not use the invite up
+	if _, err := c.RedeemInvite(defaultWorkspaceId, 3, invite.Code); err != nil {
+		t.Fatalf("RedeemInvite() error = %v", err)
+	}
+
+	room.mu.Lock()
+	role := room.RoleOf(3)
+	room.mu.Unlock()
+	if role != pb.Role_ROLE_MEMBER {
+		t.Errorf("role after RedeemInvite() = %v, want member", role)
+	}
+
+	if _, err := c.RedeemInvite(defaultWorkspaceId, 4, invite.Code); status.Code(err) != codes.NotFound {
+		t.Errorf("RedeemInvite() of a used up invite error = %v, want NotFound", err)
+	}
+}
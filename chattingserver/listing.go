@@ -0,0 +1,116 @@
+package chattingserver
+
+import (
+	"encoding/base64"
+	pb "grpc-example/chatting"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+func encodePageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+
+	offset, err := strconv.Atoi(string(b))
+	if err != nil || offset < 0 {
+		return 0, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+
+	return offset, nil
+}
+
+// roomLess orders rooms by the requested key, falling back to the room id so
+// that pages are stable.
+func roomLess(sortBy pb.RoomSort, a *pb.Room, b *pb.Room) bool {
+	switch sortBy {
+	case pb.RoomSort_ROOM_SORT_CREATED:
+		if !a.CreatedAt.AsTime().Equal(b.CreatedAt.AsTime()) {
+			return a.CreatedAt.AsTime().Before(b.CreatedAt.AsTime())
+		}
+	case pb.RoomSort_ROOM_SORT_MEMBER_COUNT:
+		if a.MemberCount != b.MemberCount {
+			return a.MemberCount < b.MemberCount
+		}
+	case pb.RoomSort_ROOM_SORT_ACTIVITY:
+		if !a.LastActivityAt.AsTime().Equal(b.LastActivityAt.AsTime()) {
+			return a.LastActivityAt.AsTime().Before(b.LastActivityAt.AsTime())
+		}
+	default:
+		if a, b := strings.ToLower(a.RoomName), strings.ToLower(b.RoomName); a != b {
+			return a < b
+		}
+	}
+
+	return a.RoomId < b.RoomId
+}
+
+// PageRooms filters, sorts and pages rooms as requested.
+func PageRooms(rooms []*pb.Room, req *pb.GetChatRoomRequest) (*pb.GetChatRoomResponse, error) {
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page size can not be negative")
+	}
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	filter := strings.ToLower(req.NameContains)
+	matched := rooms[:0]
+	for _, room := range rooms {
+		if room.Archived && !req.IncludeArchived {
+			continue
+		}
+		if strings.Contains(strings.ToLower(room.RoomName), filter) {
+			matched = append(matched, room)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if req.Descending {
+			return roomLess(req.Sort, matched[j], matched[i])
+		}
+		return roomLess(req.Sort, matched[i], matched[j])
+	})
+
+	resp := &pb.GetChatRoomResponse{}
+	if offset >= len(matched) {
+		return resp, nil
+	}
+
+	end := offset + pageSize
+	if end < len(matched) {
+		resp.NextPageToken = encodePageToken(end)
+	} else {
+		end = len(matched)
+	}
+	resp.Rooms = matched[offset:end]
+
+	return resp, nil
+}
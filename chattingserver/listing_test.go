@@ -0,0 +1,105 @@
+package chattingserver
+
+import (
+	pb "grpc-example/chatting"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testRooms() []*pb.Room {
+	return []*pb.Room{
+		{RoomId: 1, RoomName: "random", MemberCount: 3},
+		{RoomId: 2, RoomName: "General", MemberCount: 10},
+		{RoomId: 3, RoomName: "general-old", MemberCount: 1, Archived: true},
+		{RoomId: 4, RoomName: "dev", MemberCount: 3},
+	}
+}
+
+func roomIds(rooms []*pb.Room) []int64 {
+	var ids []int64
+	for _, room := range rooms {
+		ids = append(ids, room.RoomId)
+	}
+	return ids
+}
+
+func TestPageRooms(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *pb.GetChatRoomRequest
+		want []int64
+	}{
+		{"by name", &pb.GetChatRoomRequest{}, []int64{4, 2, 1}},
+		{"descending", &pb.GetChatRoomRequest{Descending: true}, []int64{1, 2, 4}},
+		{"archived", &pb.GetChatRoomRequest{IncludeArchived: true}, []int64{4, 2, 3, 1}},
+		{"name contains", &pb.GetChatRoomRequest{NameContains: "GEN", IncludeArchived: true}, []int64{2, 3}},
+		// equal member counts fall back to the room id
+		{"by members", &pb.GetChatRoomRequest{Sort: pb.RoomSort_ROOM_SORT_MEMBER_COUNT}, []int64{1, 4, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := PageRooms(testRooms(), tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := roomIds(resp.Rooms); !slices.Equal(got, tt.want) {
+				t.Errorf("PageRooms() = %v, want %v", got, tt.want)
+			}
+			if resp.NextPageToken != "" {
+				t.Errorf("PageRooms() next page token = %q, want none", resp.NextPageToken)
+			}
+		})
+	}
+}
+
+func TestPageRoomsPages(t *testing.T) {
+	req := &pb.GetChatRoomRequest{PageSize: 2, IncludeArchived: true}
+
+	var got []int64
+	for page := 0; ; page++ {
+		if page == 3 {
+			t.Fatal("PageRooms() did not reach the last page")
+		}
+
+		resp, err := PageRooms(testRooms(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Rooms) > 2 {
+			t.Fatalf("PageRooms() returned %v rooms, want at most 2", len(resp.Rooms))
+		}
+		got = append(got, roomIds(resp.Rooms)...)
+
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+
+	if want := []int64{4, 2, 3, 1}; !slices.Equal(got, want) {
+		t.Errorf("pages = %v, want %v", got, want)
+	}
+}
+
+func TestPageRoomsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *pb.GetChatRoomRequest
+	}{
+		{"negative page size", &pb.GetChatRoomRequest{PageSize: -1}},
+		{"bad page token", &pb.GetChatRoomRequest{PageToken: "!!!"}},
+		{"negative offset", &pb.GetChatRoomRequest{PageToken: encodePageToken(-1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := PageRooms(testRooms(), tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("PageRooms() error = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
@@ -13,7 +13,12 @@ func CustomUnaryMiddleware() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ interface{}, err error) {
 		log.Print("Requested at:", time.Now())
 
-		fmt.Println("req : ", req)
+		// requests carrying passwords or refresh tokens are not logged
+		_, password := req.(interface{ GetPassword() string })
+		_, refresh := req.(interface{ GetRefreshToken() string })
+		if !password && !refresh {
+			fmt.Println("req : ", req)
+		}
 		fmt.Println("info : ", info)
 
 		resp, err := handler(ctx, req)
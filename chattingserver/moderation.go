@@ -0,0 +1,111 @@
+package chattingserver
+
+import (
+	"context"
+	pb "grpc-example/chatting"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Outranks reports whether actor holds a higher role than target. The caller
+// must hold the room lock.
+func (r *Room) Outranks(actor int64, target int64) bool {
+	return r.RoleOf(actor) > r.RoleOf(target)
+}
+
+// IsBanned reports whether userId is banned from the room. The caller must
+// hold the room lock.
+func (r *Room) IsBanned(userId int64) bool {
+	_, ok := r.Banned[userId]
+	return ok
+}
+
+// MutedUntil returns when the mute of userId expires, or false if the user
+// is not muted. The caller must hold the room lock.
+func (r *Room) MutedUntil(userId int64) (time.Time, bool) {
+	until, ok := r.Muted[userId]
+	if !ok {
+		return time.Time{}, false
+	}
+	if time.Now().After(until) {
+		delete(r.Muted, userId)
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// Kick removes userId from the room and ends the streams of all its devices
+// with cause. It reports whether the user was in the room. The caller must
+// hold the room lock.
+func (r *Room) Kick(userId int64, cause error) bool {
+	devices, ok := r.Users[userId]
+	if !ok {
+		return false
+	}
+
+	delete(r.Users, userId)
+	for _, user := range devices {
+		if user.cancel != nil {
+			user.cancel(cause)
+		}
+	}
+	r.admitWaiters()
+
+	return true
+}
+
+// moderate runs action with the room lock held, once the caller is allowed
+// to use perm on target.
+func (s *chattingServer) moderate(ctx context.Context, roomId int64, target int64, perm Permission, action func(room *Room)) (*Room, error) {
+	userId, err := s.GetUserId(&ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceId, err := s.GetWorkspace(&ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := s.FindRoom(workspaceId, roomId)
+	if err != nil {
+		return nil, err
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if err := room.Authorize(userId, perm); err != nil {
+		return nil, err
+	}
+
+	if !room.Outranks(userId, target) {
+		return nil, status.Errorf(codes.PermissionDenied, "user %v does not outrank user %v", userId, target)
+	}
+
+	action(room)
+
+	return room, nil
+}
+
+// kickedError is the status a kicked user's stream ends with.
+func kickedError(roomId int64, reason string) error {
+	if reason == "" {
+		return status.Errorf(codes.PermissionDenied, "kicked from room %v", roomId)
+	}
+	return status.Errorf(codes.PermissionDenied, "kicked from room %v: %v", roomId, reason)
+}
+
+func bannedError(roomId int64) error {
+	return status.Errorf(codes.PermissionDenied, "banned from room %v", roomId)
+}
+
+// Notify sends a notice to user alone.
+func (r *Room) Notify(user *UserInRoom, text string) {
+	r.Deliver(user, &pb.Message{
+		Msg:  text,
+		Kind: pb.MessageKind_MESSAGE_KIND_NOTICE,
+	})
+}
@@ -0,0 +1,111 @@
+package chattingserver
+
+import (
+	"context"
+	pb "grpc-example/chatting"
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stream gives user a stream context that moderation can end.
+func stream(room *Room, user *UserInRoom) context.Context {
+	ctx, cancel := context.WithCancelCause(context.Background())
+
+	room.mu.Lock()
+	user.cancel = cancel
+	room.mu.Unlock()
+
+	return ctx
+}
+
+func TestKickUser(t *testing.T) {
+	c := newTestServer(t)
+	room := newTestRoom(t, c, 1)
+	owner := enter(t, c, room, 1, 10)
+	phone, laptop := stream(room, enter(t, c, room, 2, 20)), stream(room, enter(t, c, room, 2, 21))
+	enter(t, c, room, 3, 30)
+
+	req := &pb.ModerationRequest{RoomId: room.RoomId, UserId: 2, Reason: "spam"}
+	if _, err := c.KickUser(asUser(3, 30), req); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("KickUser() by a member error = %v, want PermissionDenied", err)
+	}
+	if _, err := c.KickUser(asUser(1, 10), req); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ctx := range []context.Context{phone, laptop} {
+		if cause := context.Cause(ctx); status.Code(cause) != codes.PermissionDenied || !strings.Contains(cause.Error(), "spam") {
+			t.Errorf("stream ended with %v, want the kick", cause)
+		}
+	}
+
+	room.mu.Lock()
+	_, inRoom := room.Users[2]
+	room.mu.Unlock()
+	if inRoom {
+		t.Error("kicked user is still in the room")
+	}
+
+	if got := room.Drain(owner); !slices.ContainsFunc(got, func(msg *pb.Message) bool { return strings.HasSuffix(msg.Msg, "was kicked") }) {
+		t.Error("the kick was not announced to the room")
+	}
+
+	// kicked users may come back
+	enter(t, c, room, 2, 20)
+}
+
+func TestBanUser(t *testing.T) {
+	c := newTestServer(t)
+	room := newTestRoom(t, c, 1)
+	banned := stream(room, enter(t, c, room, 2, 20))
+
+	req := &pb.ModerationRequest{RoomId: room.RoomId, UserId: 2}
+	if _, err := c.BanUser(asUser(1, 10), req); err != nil {
+		t.Fatal(err)
+	}
+	if cause := context.Cause(banned); status.Code(cause) != codes.PermissionDenied {
+		t.Errorf("stream ended with %v, want the ban", cause)
+	}
+
+	if _, _, err := c.EnterRoom(defaultWorkspaceId, 2, 20, room.RoomId); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("EnterRoom() by a banned user error = %v, want PermissionDenied", err)
+	}
+
+	if _, err := c.UnbanUser(asUser(1, 10), req); err != nil {
+		t.Fatal(err)
+	}
+	enter(t, c, room, 2, 20)
+}
+
+func TestMuteUser(t *testing.T) {
+	c := newTestServer(t)
+	room := newTestRoom(t, c, 1)
+	muted := enter(t, c, room, 2, 20)
+
+	if _, err := c.MuteUser(asUser(1, 10), &pb.MuteUserRequest{RoomId: room.RoomId, UserId: 2, DurationSeconds: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("MuteUser() for a negative duration error = %v, want InvalidArgument", err)
+	}
+
+	if _, err := c.MuteUser(asUser(1, 10), &pb.MuteUserRequest{RoomId: room.RoomId, UserId: 2, DurationSeconds: 60}); err != nil {
+		t.Fatal(err)
+	}
+	c.Receive(room, muted, 2, &pb.Message{Msg: "hi"})
+
+	got := room.Drain(muted)
+	if len(chats(got)) != 0 || len(notices(got)) != 2 {
+		t.Fatalf("muted user got chats %v and notices %v, want the mute and the refusal", chats(got), notices(got))
+	}
+
+	if _, err := c.MuteUser(asUser(1, 10), &pb.MuteUserRequest{RoomId: room.RoomId, UserId: 2}); err != nil {
+		t.Fatal(err)
+	}
+	c.Receive(room, muted, 2, &pb.Message{Msg: "hi"})
+
+	if got := chats(room.Drain(muted)); !slices.Equal(got, []string{"hi"}) {
+		t.Errorf("unmuted user got %v, want the message", got)
+	}
+}
@@ -0,0 +1,57 @@
+package chattingserver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHashPassword(t *testing.T) {
+	hash, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=3,p=4$") {
+		t.Errorf("HashPassword() = %q, want argon2id parameters", hash)
+	}
+
+	again, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again == hash {
+		t.Error("HashPassword() hashed twice without a new salt")
+	}
+}
+
+func TestVerifyPassword(t *testing.T) {
+	hash, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(hash, "$")
+
+	tests := []struct {
+		name     string
+		hash     string
+		password string
+		want     bool
+	}{
+		{"match", hash, "correct horse", true},
+		{"wrong password", hash, "correct horse!", false},
+		{"empty password", hash, "", false},
+		{"empty hash", "", "correct horse", false},
+		{"other algorithm", strings.Replace(hash, "argon2id", "argon2i", 1), "correct horse", false},
+		{"other version", strings.Replace(hash, "v=19", "v=16", 1), "correct horse", false},
+		{"bad parameters", strings.Replace(hash, "m=65536", "m=x", 1), "correct horse", false},
+		{"bad salt", strings.Join(append(parts[:4:4], "!!!", parts[5]), "$"), "correct horse", false},
+		{"bad key", strings.Join(append(parts[:5:5], "!!!"), "$"), "correct horse", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyPassword(tt.hash, tt.password); got != tt.want {
+				t.Errorf("VerifyPassword() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
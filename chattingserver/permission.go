@@ -0,0 +1,105 @@
+package chattingserver
+
+import (
+	"fmt"
+	pb "grpc-example/chatting"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Permission int
+
+const (
+	PermRemoveRoom Permission = iota
+	PermRenameRoom
+	PermKick
+	PermPin
+	PermManageRoles
+	PermInvite
+	PermBan
+	PermMute
+	PermArchive
+	PermAnnounce
+	PermSkipSlowMode
+	// PermConfigureRoom changes who may enter and post in a room: its
+	// visibility, capacity and posting policies.
+	PermConfigureRoom
+)
+
+func (p Permission) String() string {
+	switch p {
+	case PermRemoveRoom:
+		return "remove room"
+	case PermRenameRoom:
+		return "rename room"
+	case PermKick:
+		return "kick"
+	case PermPin:
+		return "pin"
+	case PermManageRoles:
+		return "manage roles"
+	case PermInvite:
+		return "invite"
+	case PermBan:
+		return "ban"
+	case PermMute:
+		return "mute"
+	case PermArchive:
+		return "archive"
+	case PermAnnounce:
+		return "announce"
+	case PermSkipSlowMode:
+		return "skip slow mode"
+	case PermConfigureRoom:
+		return "configure room"
+	}
+	return fmt.Sprintf("permission(%d)", int(p))
+}
+
+var rolePermissions = map[pb.Role][]Permission{
+	pb.Role_ROLE_MEMBER:    {},
+	pb.Role_ROLE_MODERATOR: {PermRenameRoom, PermKick, PermPin, PermInvite, PermBan, PermMute, PermAnnounce, PermSkipSlowMode},
+	pb.Role_ROLE_OWNER:     {PermRemoveRoom, PermRenameRoom, PermKick, PermPin, PermManageRoles, PermInvite, PermBan, PermMute, PermArchive, PermAnnounce, PermSkipSlowMode, PermConfigureRoom},
+}
+
+func HasPermission(role pb.Role, perm Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleOf returns the role of userId in the room, or ROLE_UNSPECIFIED for
+// users that never joined it. The caller must hold the room lock.
+func (r *Room) RoleOf(userId int64) pb.Role {
+	return r.Roles[userId]
+}
+
+// Authorize returns a PermissionDenied error unless userId holds a role with
+// perm in the room. The caller must hold the room lock.
+func (r *Room) Authorize(userId int64, perm Permission) error {
+	if HasPermission(r.RoleOf(userId), perm) {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "%v requires a higher role in room %v", perm, r.RoomId)
+}
+
+// CanView reports whether userId may list, read and enter the room. Private
+// rooms are restricted to their members. The caller must hold the room lock.
+func (r *Room) CanView(userId int64) bool {
+	return r.Visibility != pb.Visibility_VISIBILITY_PRIVATE || r.RoleOf(userId) != pb.Role_ROLE_UNSPECIFIED
+}
+
+// countRole returns how many users hold role. The caller must hold the room lock.
+func (r *Room) countRole(role pb.Role) int {
+	n := 0
+	for _, rl := range r.Roles {
+		if rl == role {
+			n++
+		}
+	}
+	return n
+}
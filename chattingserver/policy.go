@@ -0,0 +1,60 @@
+package chattingserver
+
+import (
+	"fmt"
+	pb "grpc-example/chatting"
+	"time"
+)
+
+// checkPosting returns why userId may not post to the room now, or "" when
+// it may. The caller must hold the room lock.
+func (r *Room) checkPosting(userId int64, now time.Time) string {
+	role := r.RoleOf(userId)
+
+	if r.Announcement && !HasPermission(role, PermAnnounce) {
+		return "this is an announcement room; only moderators may post"
+	}
+
+	if r.SlowMode > 0 && !HasPermission(role, PermSkipSlowMode) {
+		next := r.LastPost[userId].Add(r.SlowMode)
+		if now.Before(next) {
+			wait := next.Sub(now).Truncate(time.Second) + time.Second
+			return fmt.Sprintf("slow mode is on; you may post again in %v", wait)
+		}
+	}
+
+	return ""
+}
+
+// Post publishes msg unless the posting policy of the room keeps its sender
+// from posting now, and returns why it did not. A post published in slow
+// mode starts the wait for the next one.
+func (r *Room) Post(msg *pb.Message) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if blocked := r.checkPosting(msg.UserId, now); blocked != "" {
+		return blocked
+	}
+
+	r.publish(msg)
+	if r.SlowMode > 0 {
+		r.LastPost[msg.UserId] = now
+	}
+
+	return ""
+}
+
+// policyText describes the posting policy of the room.
+// The caller must hold the room lock.
+func (r *Room) policyText() string {
+	slow := "slow mode is off"
+	if r.SlowMode > 0 {
+		slow = fmt.Sprintf("slow mode is %v", r.SlowMode)
+	}
+	if r.Announcement {
+		return slow + "; only moderators may post"
+	}
+	return slow + "; everyone may post"
+}
@@ -0,0 +1,64 @@
+package chattingserver
+
+import (
+	pb "grpc-example/chatting"
+	"testing"
+	"time"
+)
+
+func TestPostSlowMode(t *testing.T) {
+	room := NewRoom("test", 1)
+	room.Roles[2] = pb.Role_ROLE_MEMBER
+	room.SlowMode = time.Minute
+
+	if blocked := room.Post(&pb.Message{UserId: 2, Msg: "first"}); blocked != "" {
+		t.Fatalf("first Post() blocked: %v", blocked)
+	}
+	if blocked := room.Post(&pb.Message{UserId: 2, Msg: "second"}); blocked == "" {
+		t.Error("second Post() within the slow mode was published")
+	}
+
+	// moderators are not slowed down
+	for i := 0; i < 2; i++ {
+		if blocked := room.Post(&pb.Message{UserId: 1, Msg: "owner"}); blocked != "" {
+			t.Errorf("Post() by the owner blocked: %v", blocked)
+		}
+	}
+
+	// once the wait is over the member may post again
+	room.mu.Lock()
+	room.LastPost[2] = time.Now().Add(-time.Minute)
+	room.mu.Unlock()
+	if blocked := room.Post(&pb.Message{UserId: 2, Msg: "later"}); blocked != "" {
+		t.Errorf("Post() after the slow mode blocked: %v", blocked)
+	}
+
+	if room.Seq != 4 {
+		t.Errorf("room published %v messages, want 4", room.Seq)
+	}
+}
+
+func TestPostAnnouncement(t *testing.T) {
+	room := NewRoom("test", 1)
+	room.Roles[2] = pb.Role_ROLE_MEMBER
+	room.Announcement = true
+
+	tests := []struct {
+		name      string
+		userId    int64
+		published bool
+	}{
+		{"owner", 1, true},
+		{"member", 2, false},
+		{"visitor", 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocked := room.Post(&pb.Message{UserId: tt.userId, Msg: "news"})
+			if published := blocked == ""; published != tt.published {
+				t.Errorf("Post() published = %v (%q), want %v", published, blocked, tt.published)
+			}
+		})
+	}
+}
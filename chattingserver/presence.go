@@ -0,0 +1,198 @@
+package chattingserver
+
+import (
+	pb "grpc-example/chatting"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// HeartbeatInterval is how often clients send a heartbeat to stay
+	// online without an open stream.
+	HeartbeatInterval = 30 * time.Second
+	// heartbeatTimeout is how long after their last heartbeat such clients
+	// go offline; it lets a couple of heartbeats get lost.
+	heartbeatTimeout = 3 * HeartbeatInterval
+
+	// presenceWatcherBuffer is the number of presence events a watcher may
+	// lag behind before it is dropped.
+	presenceWatcherBuffer = 64
+	// maxWatchPresence is how many users one WatchPresence may watch.
+	maxWatchPresence = 100
+)
+
+// presence is what makes a user online.
+type presence struct {
+	// streams is the number of open streams of the user.
+	streams       int
+	lastHeartbeat time.Time
+	// heartbeat fires once the last heartbeat timed out.
+	heartbeat *time.Timer
+	// away is set by the user and kept until changed.
+	away bool
+
+	// state is the presence last announced to the watchers.
+	state    pb.Presence
+	lastSeen time.Time
+}
+
+func (p *presence) current(now time.Time) pb.Presence {
+	switch {
+	case p.streams == 0 && now.Sub(p.lastHeartbeat) >= heartbeatTimeout:
+		return pb.Presence_PRESENCE_OFFLINE
+	case p.away:
+		return pb.Presence_PRESENCE_AWAY
+	default:
+		return pb.Presence_PRESENCE_ONLINE
+	}
+}
+
+// toProto describes the presence of userId.
+// The caller must hold the presence lock.
+func (p *presence) toProto(userId int64) *pb.PresenceEvent {
+	event := &pb.PresenceEvent{UserId: userId, Presence: p.state}
+	if !p.lastSeen.IsZero() {
+		event.LastSeen = timestamppb.New(p.lastSeen)
+	}
+	return event
+}
+
+type presenceWatcher struct {
+	userIds map[int64]struct{}
+	events  chan *pb.PresenceEvent
+
+	// lagged is closed when the watcher fell too far behind
+	lagged chan struct{}
+}
+
+// updatePresence applies change to the presence of userId and tells the
+// watchers of userId when that changed whether the user is around.
+func (c *chattingServer) updatePresence(userId int64, change func(p *presence)) {
+	c.presenceMu.Lock()
+	defer c.presenceMu.Unlock()
+
+	p, ok := c.presence[userId]
+	if !ok {
+		p = &presence{}
+		c.presence[userId] = p
+	}
+	change(p)
+
+	now := time.Now()
+	state := p.current(now)
+	if state != pb.Presence_PRESENCE_OFFLINE || p.state != pb.Presence_PRESENCE_OFFLINE {
+		p.lastSeen = now
+	}
+	if state == p.state {
+		return
+	}
+	p.state = state
+
+	event := p.toProto(userId)
+	for w := range c.presenceWatchers {
+		if _, ok := w.userIds[userId]; !ok {
+			continue
+		}
+
+		select {
+		case w.events <- event:
+		default:
+			delete(c.presenceWatchers, w)
+			close(w.lagged)
+		}
+	}
+}
+
+// Connect and Disconnect count the open streams of userId.
+func (c *chattingServer) Connect(userId int64) {
+	c.updatePresence(userId, func(p *presence) { p.streams++ })
+}
+
+func (c *chattingServer) Disconnect(userId int64) {
+	c.updatePresence(userId, func(p *presence) { p.streams-- })
+}
+
+// Beat keeps userId online for heartbeatTimeout.
+func (c *chattingServer) Beat(userId int64) {
+	c.updatePresence(userId, func(p *presence) {
+		p.lastHeartbeat = time.Now()
+		if p.heartbeat == nil {
+			// re-evaluates the presence once the heartbeat timed out
+			p.heartbeat = time.AfterFunc(heartbeatTimeout, func() {
+				c.updatePresence(userId, func(*presence) {})
+			})
+		} else {
+			p.heartbeat.Reset(heartbeatTimeout)
+		}
+	})
+}
+
+func (c *chattingServer) SetAway(userId int64, away bool) {
+	c.updatePresence(userId, func(p *presence) { p.away = away })
+}
+
+// PresenceOf returns whether userId is around.
+func (c *chattingServer) PresenceOf(userId int64) pb.Presence {
+	c.presenceMu.Lock()
+	defer c.presenceMu.Unlock()
+
+	if p, ok := c.presence[userId]; ok {
+		return p.state
+	}
+	return pb.Presence_PRESENCE_OFFLINE
+}
+
+// WatchPresenceEvents watches the users and returns the watcher with their
+// presence so far.
+func (c *chattingServer) WatchPresenceEvents(userIds []int64) (*presenceWatcher, []*pb.PresenceEvent) {
+	w := &presenceWatcher{
+		userIds: map[int64]struct{}{},
+		events:  make(chan *pb.PresenceEvent, presenceWatcherBuffer),
+		lagged:  make(chan struct{}),
+	}
+
+	c.presenceMu.Lock()
+	defer c.presenceMu.Unlock()
+
+	var snapshot []*pb.PresenceEvent
+	for _, userId := range userIds {
+		if _, ok := w.userIds[userId]; ok {
+			continue
+		}
+		w.userIds[userId] = struct{}{}
+
+		if p, ok := c.presence[userId]; ok {
+			snapshot = append(snapshot, p.toProto(userId))
+		} else {
+			snapshot = append(snapshot, &pb.PresenceEvent{UserId: userId})
+		}
+	}
+
+	c.presenceWatchers[w] = struct{}{}
+
+	return w, snapshot
+}
+
+func (c *chattingServer) UnwatchPresenceEvents(w *presenceWatcher) {
+	c.presenceMu.Lock()
+	delete(c.presenceWatchers, w)
+	c.presenceMu.Unlock()
+}
+
+// PresenceStreamMiddleware keeps users online while they have a stream open.
+// It has to run after the identity middleware.
+func (c *chattingServer) PresenceStreamMiddleware() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		userId, ok := UserIdFrom(ss.Context())
+		if !ok {
+			return handler(srv, ss)
+		}
+
+		c.Connect(userId)
+		defer c.Disconnect(userId)
+
+		return handler(srv, ss)
+	}
+}
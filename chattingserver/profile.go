@@ -0,0 +1,101 @@
+package chattingserver
+
+import (
+	"fmt"
+	pb "grpc-example/chatting"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxDisplayNameLength      = 64
+	maxStatusTextLength       = 140
+	maxAvatarAttachmentLength = 2048
+	// maxBatchGetUsers is how many users one BatchGetUsers may look up.
+	maxBatchGetUsers = 100
+)
+
+// Profile is what users tell about themselves.
+type Profile struct {
+	DisplayName string
+	StatusText  string
+	// AvatarAttachment refers to the avatar image, such as its URL.
+	AvatarAttachment string
+}
+
+// Name is the name to show for the account.
+func (a *Account) Name() string {
+	if a.Profile.DisplayName != "" {
+		return a.Profile.DisplayName
+	}
+	return a.Username
+}
+
+func (a *Account) ToProto() *pb.User {
+	return &pb.User{
+		UserId:           a.UserId,
+		Username:         a.Username,
+		DisplayName:      a.Name(),
+		StatusText:       a.Profile.StatusText,
+		AvatarAttachment: a.Profile.AvatarAttachment,
+	}
+}
+
+// printable tells whether text has no control characters.
+func printable(text string) bool {
+	return strings.IndexFunc(text, unicode.IsControl) < 0
+}
+
+// UpdateProfile changes the fields of the profile of userId set in req.
+func (c *chattingServer) UpdateProfile(userId int64, req *pb.SetProfileRequest) (*Account, error) {
+	account, err := c.users.FindById(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := account.Profile
+	if req.DisplayName != nil {
+		name := strings.TrimSpace(req.GetDisplayName())
+		if utf8.RuneCountInString(name) > maxDisplayNameLength || !printable(name) {
+			return nil, status.Errorf(codes.InvalidArgument, "display name must be at most %v printable characters", maxDisplayNameLength)
+		}
+		profile.DisplayName = name
+	}
+	if req.StatusText != nil {
+		text := strings.TrimSpace(req.GetStatusText())
+		if utf8.RuneCountInString(text) > maxStatusTextLength || !printable(text) {
+			return nil, status.Errorf(codes.InvalidArgument, "status text must be at most %v printable characters", maxStatusTextLength)
+		}
+		profile.StatusText = text
+	}
+	if req.AvatarAttachment != nil {
+		avatar := strings.TrimSpace(req.GetAvatarAttachment())
+		if len(avatar) > maxAvatarAttachmentLength || !printable(avatar) {
+			return nil, status.Errorf(codes.InvalidArgument, "avatar attachment must be at most %v characters", maxAvatarAttachmentLength)
+		}
+		profile.AvatarAttachment = avatar
+	}
+
+	return c.users.UpdateProfile(userId, profile)
+}
+
+// DisplayName returns the name to show for userId, or "" for unknown users.
+func (c *chattingServer) DisplayName(userId int64) string {
+	account, err := c.users.FindById(userId)
+	if err != nil {
+		return ""
+	}
+	return account.Name()
+}
+
+// nameOf names userId in the texts of events.
+func (c *chattingServer) nameOf(userId int64) string {
+	if name := c.DisplayName(userId); name != "" {
+		return name
+	}
+	return fmt.Sprintf("user %v", userId)
+}
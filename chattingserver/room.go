@@ -0,0 +1,216 @@
+package chattingserver
+
+import (
+	pb "grpc-example/chatting"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func NewRoom(roomName string, createdBy int64) *Room {
+	now := time.Now()
+	return &Room{
+		RoomName:     roomName,
+		CreatedBy:    createdBy,
+		CreatedAt:    now,
+		LastActivity: now,
+		Users:        map[int64]map[int64]*UserInRoom{},
+		Roles:        map[int64]pb.Role{createdBy: pb.Role_ROLE_OWNER},
+		Banned:       map[int64]struct{}{},
+		Muted:        map[int64]time.Time{},
+		LastPost:     map[int64]time.Time{},
+		Reserved:     map[int64]struct{}{},
+		closed:       make(chan struct{}),
+	}
+}
+
+func (r *Room) ToProto() *pb.Room {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &pb.Room{
+		RoomId:          r.RoomId,
+		WorkspaceId:     r.WorkspaceId,
+		RoomName:        r.RoomName,
+		Slug:            r.Slug,
+		Topic:           r.Topic,
+		Description:     r.Description,
+		CreatedBy:       r.CreatedBy,
+		CreatedAt:       timestamppb.New(r.CreatedAt),
+		MemberCount:     int32(len(r.Users)),
+		Visibility:      r.Visibility,
+		MaxMembers:      r.MaxMembers,
+		LastActivityAt:  timestamppb.New(r.LastActivity),
+		Archived:        r.Archived,
+		SlowModeSeconds: int32(r.SlowMode / time.Second),
+		Announcement:    r.Announcement,
+	}
+}
+
+func NewUserInRoom() *UserInRoom {
+	return &UserInRoom{signal: make(chan struct{}, 1)}
+}
+
+// push queues msg for delivery. The caller must hold the room lock.
+func (u *UserInRoom) push(msg *pb.Message) {
+	u.Buffer = append(u.Buffer, msg)
+	select {
+	case u.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (r *Room) Members() []*pb.Member {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	members := make([]*pb.Member, 0, len(r.Users))
+	for userId := range r.Users {
+		members = append(members, &pb.Member{
+			UserId: userId,
+			Role:   r.RoleOf(userId),
+		})
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].UserId < members[j].UserId
+	})
+
+	return members
+}
+
+// Close ends every stream in the room with cause and empties the room and
+// its waitlist. The caller must hold the room lock.
+func (r *Room) Close(cause error) {
+	for userId, devices := range r.Users {
+		for _, user := range devices {
+			if user.cancel != nil {
+				user.cancel(cause)
+			}
+		}
+		delete(r.Users, userId)
+	}
+
+	r.Waitlist = nil
+	r.Reserved = map[int64]struct{}{}
+
+	close(r.closed)
+}
+
+// IsClosed reports whether the room was removed. The caller must hold the
+// room lock.
+func (r *Room) IsClosed() bool {
+	select {
+	case <-r.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func removedError(roomId int64) error {
+	return status.Errorf(codes.NotFound, "room %v was removed", roomId)
+}
+
+// Publish stamps msg with the next sequence number of the room, records it in
+// the history and fans it out to every device in the room, the ones of the
+// sender included, so that all of them observe the same order.
+func (r *Room) Publish(msg *pb.Message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.publish(msg)
+}
+
+// The caller must hold the room lock.
+func (r *Room) publish(msg *pb.Message) {
+	r.Seq++
+	msg.Seq = r.Seq
+	r.LastActivity = time.Now()
+	r.history.add(msg)
+
+	for _, devices := range r.Users {
+		for _, user := range devices {
+			user.push(msg)
+		}
+	}
+}
+
+// Devices returns the devices userId is in the room with.
+// The caller must hold the room lock.
+func (r *Room) Devices(userId int64) []*UserInRoom {
+	var devices []*UserInRoom
+	for _, user := range r.Users[userId] {
+		devices = append(devices, user)
+	}
+	return devices
+}
+
+// InRoom reports whether user is one of the devices of userId in the room.
+// The caller must hold the room lock.
+func (r *Room) InRoom(userId int64, user *UserInRoom) bool {
+	for _, device := range r.Users[userId] {
+		if device == user {
+			return true
+		}
+	}
+	return false
+}
+
+// Deliver queues msg for user alone, without stamping or recording it.
+func (r *Room) Deliver(user *UserInRoom, msg *pb.Message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user.push(msg)
+}
+
+// Drain returns the messages queued for user and empties its buffer.
+func (r *Room) Drain(user *UserInRoom) []*pb.Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	buffer := user.Buffer
+	user.Buffer = nil
+	return buffer
+}
+
+// Search returns up to limit of the chat messages kept containing query,
+// ignoring case, oldest first.
+func (r *Room) Search(query string, limit int) []*pb.Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query = strings.ToLower(query)
+
+	var found []*pb.Message
+	for _, msg := range r.history.messages {
+		if len(found) == limit {
+			break
+		}
+		if msg.Kind == pb.MessageKind_MESSAGE_KIND_CHAT && strings.Contains(strings.ToLower(msg.Msg), query) {
+			found = append(found, msg)
+		}
+	}
+
+	return found
+}
+
+// HistoryRange returns the messages with fromSeq <= seq <= toSeq. A fromSeq
+// of 0 means from the oldest message kept and a toSeq of 0 up to the latest
+// message. It fails with OutOfRange when older messages are asked for than
+// the room still keeps.
+func (r *Room) HistoryRange(fromSeq, toSeq int64) ([]*pb.Message, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if toSeq == 0 {
+		toSeq = r.Seq
+	}
+
+	return r.history.between(fromSeq, toSeq)
+}
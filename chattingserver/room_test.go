@@ -0,0 +1,211 @@
+package chattingserver
+
+import (
+	"context"
+	"fmt"
+	pb "grpc-example/chatting"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// asUser is the context of a call userId makes from device deviceId in the
+// default workspace.
+func asUser(userId int64, deviceId int64) context.Context {
+	return context.WithValue(context.Background(), callInfoKey{}, &callInfo{
+		userId:      userId,
+		sessionId:   deviceId,
+		deviceId:    deviceId,
+		workspaceId: defaultWorkspaceId,
+	})
+}
+
+func newTestRoom(t *testing.T, c *chattingServer, ownerId int64) *Room {
+	t.Helper()
+
+	room := NewRoom("test", ownerId)
+	room.Slug = "test"
+	room.WorkspaceId = defaultWorkspaceId
+	if _, err := c.CreateRoomId(room); err != nil {
+		t.Fatal(err)
+	}
+	return room
+}
+
+func enter(t *testing.T, c *chattingServer, room *Room, userId int64, deviceId int64) *UserInRoom {
+	t.Helper()
+
+	_, user, err := c.EnterRoom(defaultWorkspaceId, userId, deviceId, room.RoomId)
+	if err != nil {
+		t.Fatalf("EnterRoom(%v, %v) error = %v", userId, deviceId, err)
+	}
+	return user
+}
+
+// chats returns the texts of the chat messages among msgs.
+func chats(msgs []*pb.Message) []string {
+	var texts []string
+	for _, msg := range msgs {
+		if msg.Kind == pb.MessageKind_MESSAGE_KIND_CHAT {
+			texts = append(texts, msg.Msg)
+		}
+	}
+	return texts
+}
+
+// notices returns the texts of the notices among msgs.
+func notices(msgs []*pb.Message) []string {
+	var texts []string
+	for _, msg := range msgs {
+		if msg.Kind == pb.MessageKind_MESSAGE_KIND_NOTICE {
+			texts = append(texts, msg.Msg)
+		}
+	}
+	return texts
+}
+
+func seqs(msgs []*pb.Message) []int64 {
+	var seqs []int64
+	for _, msg := range msgs {
+		seqs = append(seqs, msg.Seq)
+	}
+	return seqs
+}
+
+func publishChats(room *Room, n int) {
+	for i := 0; i < n; i++ {
+		room.Publish(&pb.Message{Msg: fmt.Sprint(i), Kind: pb.MessageKind_MESSAGE_KIND_CHAT})
+	}
+}
+
+func TestPublishStampsSeq(t *testing.T) {
+	c := newTestServer(t)
+	room := newTestRoom(t, c, 1)
+	alice := enter(t, c, room, 1, 10)
+	bob := enter(t, c, room, 2, 20)
+
+	c.Receive(room, alice, 1, &pb.Message{Msg: "hi"})
+	c.Receive(room, bob, 2, &pb.Message{Msg: "hello"})
+	c.Receive(room, alice, 1, &pb.Message{Msg: "bye"})
+
+	got := room.Drain(alice)
+	for i, msg := range got {
+		if msg.Seq != int64(i)+got[0].Seq {
+			t.Fatalf("seqs = %v, want them without gaps", seqs(got))
+		}
+	}
+	if want := []string{"hi", "hello", "bye"}; !slices.Equal(chats(got), want) {
+		t.Errorf("alice got %v, want %v", chats(got), want)
+	}
+
+	// both members observe the same order
+	bobs := room.Drain(bob)
+	if !slices.Equal(seqs(bobs), seqs(got[len(got)-len(bobs):])) {
+		t.Errorf("bob got seqs %v, alice %v", seqs(bobs), seqs(got))
+	}
+}
+
+func TestHistoryRangeBackfill(t *testing.T) {
+	c := newTestServer(t)
+	room := newTestRoom(t, c, 1)
+	publishChats(room, 5)
+
+	tests := []struct {
+		name           string
+		fromSeq, toSeq int64
+		want           []int64
+	}{
+		{"gap", 2, 4, []int64{2, 3, 4}},
+		{"to latest", 4, 0, []int64{4, 5}},
+		{"from oldest", 0, 2, []int64{1, 2}},
+		{"past latest", 6, 0, nil},
+		{"empty range", 4, 3, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := room.HistoryRange(tt.fromSeq, tt.toSeq)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(seqs(got), tt.want) {
+				t.Errorf("HistoryRange(%v, %v) = %v, want %v", tt.fromSeq, tt.toSeq, seqs(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestHistoryRangeTrimmed(t *testing.T) {
+	c := newTestServer(t)
+	room := newTestRoom(t, c, 1)
+	publishChats(room, historySize+10)
+
+	if _, err := room.HistoryRange(5, 20); status.Code(err) != codes.OutOfRange {
+		t.Errorf("HistoryRange(5, 20) error = %v, want OutOfRange", err)
+	}
+
+	kept, err := room.HistoryRange(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kept) != historySize || kept[0].Seq != 11 {
+		t.Errorf("HistoryRange(0, 0) kept %v messages from seq %v, want %v from 11", len(kept), kept[0].Seq, historySize)
+	}
+}
+
+func TestHistoryArchivedKeepsAll(t *testing.T) {
+	c := newTestServer(t)
+	room := newTestRoom(t, c, 1)
+	publishChats(room, 10)
+
+	c.RemoveRoomId(room.RoomId, true)
+	publishChats(room, historySize)
+
+	kept, err := room.HistoryRange(1, 0)
+	if err != nil {
+		t.Fatalf("HistoryRange(1, 0) error = %v", err)
+	}
+	if len(kept) != historySize+10 {
+		t.Errorf("HistoryRange(1, 0) kept %v messages, want %v", len(kept), historySize+10)
+	}
+}
+
+func TestRemoveRoom(t *testing.T) {
+	c := newTestServer(t)
+	room := newTestRoom(t, c, 1)
+	member := stream(room, enter(t, c, room, 2, 20))
+
+	// concurrent removals close the room once
+	errs := make(chan error)
+	for i := 0; i < 10; i++ {
+		go func() {
+			_, err := c.RemoveRoom(asUser(1, 10), &pb.RemoveRoomRequest{RoomId: room.RoomId, Archive: true})
+			errs <- err
+		}()
+	}
+	removed := 0
+	for i := 0; i < 10; i++ {
+		switch err := <-errs; status.Code(err) {
+		case codes.OK:
+			removed++
+		case codes.NotFound:
+		default:
+			t.Errorf("RemoveRoom() error = %v, want NotFound", err)
+		}
+	}
+	if removed != 1 {
+		t.Errorf("%v RemoveRoom() calls succeeded, want 1", removed)
+	}
+
+	if cause := context.Cause(member); status.Code(cause) != codes.NotFound {
+		t.Errorf("stream ended with %v, want the removal", cause)
+	}
+	if _, _, err := c.EnterRoom(defaultWorkspaceId, 2, 20, room.RoomId); status.Code(err) != codes.NotFound {
+		t.Errorf("EnterRoom() of a removed room error = %v, want NotFound", err)
+	}
+	if _, err := c.FindArchivedRoom(defaultWorkspaceId, room.RoomId); err != nil {
+		t.Errorf("FindArchivedRoom() error = %v", err)
+	}
+}
@@ -1,17 +1,63 @@
 package chattingserver
 
 import (
+	"context"
 	pb "grpc-example/chatting"
 	"sync"
+	"time"
 )
 
 type UserInRoom struct {
 	Buffer []*pb.Message
+
+	signal chan struct{}
+	// cancel ends the Chatting stream of the user, if one is open
+	cancel context.CancelCauseFunc
 }
 
 type Room struct {
-	RoomName string
-	Users    map[int32]*UserInRoom
+	RoomId int64
+	// WorkspaceId is the workspace owning the room; it never changes.
+	WorkspaceId int64
+	RoomName    string
+	// Slug is the unique name of the room in its workspace; it stays the
+	// same when the room is renamed.
+	Slug        string
+	Topic       string
+	Description string
+	CreatedBy   int64
+	CreatedAt   time.Time
+	Visibility  pb.Visibility
+	MaxMembers  int32
+	// LastActivity is when the last message was published to the room.
+	LastActivity time.Time
+	Archived     bool
+	// SlowMode is how long members wait between two messages.
+	SlowMode     time.Duration
+	Announcement bool
+
+	// Users holds the users in the room and, for each of them, the devices
+	// they are in the room with, by their DeviceIdFrom.
+	Users map[int64]map[int64]*UserInRoom
+	// Roles holds the role of every user that ever joined the room.
+	Roles map[int64]pb.Role
+
+	Banned map[int64]struct{}
+	// Muted holds when the mute of each muted user expires.
+	Muted map[int64]time.Time
+	// LastPost holds when each user last posted, for slow mode.
+	LastPost map[int64]time.Time
+
+	// Reserved holds the slots kept for users admitted from the Waitlist.
+	Reserved map[int64]struct{}
+	Waitlist []*waiter
+
+	// closed is closed once the room was removed
+	closed chan struct{}
+
+	// Seq is the sequence number of the last message published to the room.
+	Seq     int64
+	history history
 
 	mu sync.Mutex
 }
@@ -19,13 +65,63 @@ type Room struct {
 type chattingServer struct {
 	pb.UnimplementedChattingServer
 
-	Users map[int32]struct{}
-	Rooms map[int32]*Room
+	// Users holds the users that are logged in, that is who have a session.
+	Users      map[int64]struct{}
+	Workspaces map[int64]*Workspace
+	Rooms      map[int64]*Room
+	Invites    map[string]*Invite
+	// Archives holds removed rooms whose history was kept.
+	Archives map[int64]*Room
+
+	mu sync.RWMutex
+
+	// ids hands out the ids of users, workspaces, rooms and messages.
+	ids IDGenerator
+	// users keeps the registered accounts.
+	users  UserStore
+	tokens *TokenSigner
+
+	sessions map[int64]*Session
+	// revoked is the deny list of ended sessions, holding when the last
+	// token issued for each expires.
+	revoked    map[int64]time.Time
+	sessionTTL time.Duration
+
+	dedup   map[dedupSender]*dedupWindow
+	dedupMu sync.Mutex
+
+	watchers map[*roomWatcher]struct{}
+	watchMu  sync.Mutex
+
+	presence         map[int64]*presence
+	presenceWatchers map[*presenceWatcher]struct{}
+	presenceMu       sync.Mutex
 }
 
-func NewServer() *chattingServer {
+func NewServer(ids IDGenerator, users UserStore, tokens *TokenSigner, sessionTTL time.Duration) *chattingServer {
+	if sessionTTL <= 0 {
+		sessionTTL = DefaultSessionTTL
+	}
+
 	s := &chattingServer{
-		Users: make(map[int32]struct{}),
-		Rooms: map[int32]*Room{}}
+		ids:        ids,
+		users:      users,
+		tokens:     tokens,
+		sessions:   map[int64]*Session{},
+		revoked:    map[int64]time.Time{},
+		sessionTTL: sessionTTL,
+		Users:      make(map[int64]struct{}),
+		Workspaces: map[int64]*Workspace{
+			defaultWorkspaceId: {WorkspaceId: defaultWorkspaceId, Name: "default", CreatedAt: time.Now()},
+		},
+		Rooms:    map[int64]*Room{},
+		Invites:  map[string]*Invite{},
+		Archives: map[int64]*Room{},
+		dedup:    map[dedupSender]*dedupWindow{},
+		watchers: map[*roomWatcher]struct{}{},
+
+		presence:         map[int64]*presence{},
+		presenceWatchers: map[*presenceWatcher]struct{}{},
+	}
 	return s
 }
@@ -0,0 +1,255 @@
+package chattingserver
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	pb "grpc-example/chatting"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DefaultSessionTTL is how long a session lasts without being refreshed.
+const DefaultSessionTTL = 30 * 24 * time.Hour
+
+var (
+	errLoggedOut      = status.Error(codes.Unauthenticated, "logged out; log in again")
+	errSessionRevoked = status.Error(codes.Unauthenticated, "session revoked; log in again")
+	errSessionExpired = status.Error(codes.Unauthenticated, "session expired; log in again")
+	errRefreshInvalid = status.Error(codes.Unauthenticated, "invalid refresh token; log in again")
+)
+
+// Session is one login of a user, usually one device. Its session tokens are
+// short lived; the refresh token keeps it going until it expires or is
+// revoked.
+type Session struct {
+	SessionId int64
+	UserId    int64
+	// Device is the user agent the session logged in with.
+	Device      string
+	CreatedAt   time.Time
+	RefreshedAt time.Time
+	ExpiresAt   time.Time
+
+	// refreshHash is the SHA-256 of the secret of the current refresh token,
+	// and usedHash the one of the refresh token traded in for it.
+	refreshHash [sha256.Size]byte
+	usedHash    [sha256.Size]byte
+	// expiry ends the session once ExpiresAt passes.
+	expiry *time.Timer
+
+	// ctx is cancelled once the session ends, with the reason as its cause.
+	ctx context.Context
+	end context.CancelCauseFunc
+}
+
+// ToProto describes the session, marking it as current for currentId.
+// The caller must hold the server lock.
+func (s *Session) ToProto(currentId int64) *pb.DeviceSession {
+	return &pb.DeviceSession{
+		SessionId:   s.SessionId,
+		Device:      s.Device,
+		CreatedAt:   timestamppb.New(s.CreatedAt),
+		RefreshedAt: timestamppb.New(s.RefreshedAt),
+		ExpiresAt:   timestamppb.New(s.ExpiresAt),
+		Current:     s.SessionId == currentId,
+	}
+}
+
+// newRefreshToken returns a new refresh token of the session, which is
+// "<sessionId>.<secret>", and stores the hash of its secret.
+// The caller must hold the server lock.
+func (s *Session) newRefreshToken() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", status.Errorf(codes.Internal, "can not create refresh token: %v", err)
+	}
+
+	s.usedHash = s.refreshHash
+	s.refreshHash = sha256.Sum256(secret)
+	return strconv.FormatInt(s.SessionId, 10) + "." + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// deviceOf names the device making the call by its user agent.
+func deviceOf(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, key := range []string{"grpcgateway-user-agent", "user-agent"} {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	return "unknown"
+}
+
+// StartSession logs userId in from device and returns the new session with
+// its refresh token.
+func (c *chattingServer) StartSession(userId int64, device string) (*Session, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	session := &Session{
+		SessionId:   c.ids.NextID(),
+		UserId:      userId,
+		Device:      device,
+		CreatedAt:   now,
+		RefreshedAt: now,
+		ExpiresAt:   now.Add(c.sessionTTL),
+	}
+	session.ctx, session.end = context.WithCancelCause(context.Background())
+
+	refreshToken, err := session.newRefreshToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	sessionId := session.SessionId
+	session.expiry = time.AfterFunc(c.sessionTTL, func() { c.expireSession(sessionId) })
+
+	c.sessions[sessionId] = session
+	c.Users[userId] = struct{}{}
+
+	return session, refreshToken, nil
+}
+
+// RenewSession trades the refresh token for a new one and extends the
+// session. A refresh token that was already traded in means it leaked, so
+// the session is revoked; any other wrong secret is just refused, so that
+// guessing a session id is not enough to log it out.
+func (c *chattingServer) RenewSession(refreshToken string) (*Session, string, error) {
+	sessionIdStr, encSecret, ok := strings.Cut(refreshToken, ".")
+	if !ok {
+		return nil, "", errRefreshInvalid
+	}
+	sessionId, err := strconv.ParseInt(sessionIdStr, 10, 64)
+	if err != nil {
+		return nil, "", errRefreshInvalid
+	}
+	secret, err := base64.RawURLEncoding.DecodeString(encSecret)
+	if err != nil {
+		return nil, "", errRefreshInvalid
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	session, ok := c.sessions[sessionId]
+	if !ok {
+		return nil, "", errRefreshInvalid
+	}
+
+	hash := sha256.Sum256(secret)
+	if subtle.ConstantTimeCompare(hash[:], session.refreshHash[:]) != 1 {
+		if subtle.ConstantTimeCompare(hash[:], session.usedHash[:]) == 1 {
+			c.endSession(session, errSessionRevoked)
+		}
+		return nil, "", errRefreshInvalid
+	}
+
+	refreshToken, err = session.newRefreshToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	session.RefreshedAt = now
+	session.ExpiresAt = now.Add(c.sessionTTL)
+	session.expiry.Reset(c.sessionTTL)
+
+	return session, refreshToken, nil
+}
+
+// EndSession revokes the session of userId for cause. It fails when userId
+// has no such session.
+func (c *chattingServer) EndSession(userId int64, sessionId int64, cause error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	session, ok := c.sessions[sessionId]
+	if !ok || session.UserId != userId {
+		return status.Errorf(codes.NotFound, "session %v not found", sessionId)
+	}
+
+	c.endSession(session, cause)
+	return nil
+}
+
+func (c *chattingServer) expireSession(sessionId int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the session may have been refreshed while the timer fired
+	session, ok := c.sessions[sessionId]
+	if ok && !time.Now().Before(session.ExpiresAt) {
+		c.endSession(session, errSessionExpired)
+	}
+}
+
+// endSession drops the session and puts it on the deny list until the last
+// token issued for it expires, and ends its streams with cause.
+// The caller must hold the server lock.
+func (c *chattingServer) endSession(session *Session, cause error) {
+	now := time.Now()
+
+	session.expiry.Stop()
+	delete(c.sessions, session.SessionId)
+
+	for sessionId, until := range c.revoked {
+		if now.After(until) {
+			delete(c.revoked, sessionId)
+		}
+	}
+	c.revoked[session.SessionId] = now.Add(c.tokens.ttl)
+
+	if len(c.UserSessions(session.UserId)) == 0 {
+		delete(c.Users, session.UserId)
+
+		c.forgetDedup(func(sender dedupSender) bool { return sender.userId == session.UserId })
+
+		// logged out users do not wait for their heartbeat to time out
+		c.updatePresence(session.UserId, func(p *presence) { p.lastHeartbeat = time.Time{} })
+	}
+
+	session.end(cause)
+
+	// leaving takes the locks of the rooms, so it can not be done here
+	go c.ExitRooms(session.UserId, session.SessionId)
+}
+
+// UserSessions returns the open sessions of userId.
+// The caller must hold the server lock.
+func (c *chattingServer) UserSessions(userId int64) []*Session {
+	var sessions []*Session
+	for _, session := range c.sessions {
+		if session.UserId == userId {
+			sessions = append(sessions, session)
+		}
+	}
+
+	return sessions
+}
+
+// FindSession returns the open session, or nil when it ended or was started
+// by another server.
+func (c *chattingServer) FindSession(sessionId int64) *Session {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.sessions[sessionId]
+}
+
+// sessionRevoked tells whether the session is on the deny list.
+// The caller must hold the server lock.
+func (c *chattingServer) sessionRevoked(sessionId int64) bool {
+	_, ok := c.revoked[sessionId]
+	return ok
+}
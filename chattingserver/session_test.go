@@ -0,0 +1,100 @@
+package chattingserver
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newTestServer(t *testing.T) *chattingServer {
+	t.Helper()
+
+	ids, err := NewSnowflake(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewServer(ids, NewMemoryUserStore(), newTestSigner(t, "key"), time.Hour)
+}
+
+func TestRenewSessionRotates(t *testing.T) {
+	c := newTestServer(t)
+
+	session, first, err := c.StartSession(1, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	renewed, second, err := c.RenewSession(first)
+	if err != nil {
+		t.Fatalf("RenewSession(first) error = %v", err)
+	}
+	if renewed.SessionId != session.SessionId {
+		t.Errorf("RenewSession(first) renewed session %v, want %v", renewed.SessionId, session.SessionId)
+	}
+	if second == first {
+		t.Fatal("RenewSession(first) returned the same refresh token")
+	}
+
+	if _, third, err := c.RenewSession(second); err != nil || third == second {
+		t.Fatalf("RenewSession(second) = %q, %v, want a new token", third, err)
+	}
+}
+
+func TestRenewSessionRevokesReuse(t *testing.T) {
+	c := newTestServer(t)
+
+	session, first, err := c.StartSession(1, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, second, err := c.RenewSession(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// trading in a token twice means it leaked
+	if _, _, err := c.RenewSession(first); err != errRefreshInvalid {
+		t.Errorf("RenewSession(first) again error = %v, want %v", err, errRefreshInvalid)
+	}
+	if c.FindSession(session.SessionId) != nil {
+		t.Error("session survived the reuse of its refresh token")
+	}
+	if _, _, err := c.RenewSession(second); err != errRefreshInvalid {
+		t.Errorf("RenewSession(second) after reuse error = %v, want %v", err, errRefreshInvalid)
+	}
+}
+
+func TestRenewSessionRefusesWrongTokens(t *testing.T) {
+	c := newTestServer(t)
+
+	session, refreshToken, err := c.StartSession(1, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessionId := strconv.FormatInt(session.SessionId, 10)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"no secret", sessionId},
+		{"bad session id", "x.AAAA"},
+		{"unknown session", "1.AAAA"},
+		{"bad base64", sessionId + ".!!!"},
+		// guessing the session id is not enough to log it out
+		{"wrong secret", sessionId + ".AAAA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := c.RenewSession(tt.token); err != errRefreshInvalid {
+				t.Errorf("RenewSession(%q) error = %v, want %v", tt.token, err, errRefreshInvalid)
+			}
+		})
+	}
+
+	if _, _, err := c.RenewSession(refreshToken); err != nil {
+		t.Errorf("RenewSession() after wrong tokens error = %v", err)
+	}
+}
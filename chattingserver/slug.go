@@ -0,0 +1,69 @@
+package chattingserver
+
+import (
+	"strings"
+	"unicode"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxSlugLength is the longest slug a room may have, in runes.
+const maxSlugLength = 64
+
+// Slugify turns a room name into its slug: lower case letters and digits
+// with single dashes between words, such as "team-standup". A leading '#'
+// is dropped so that "#general" and "general" name the same room.
+func Slugify(name string) string {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "#")
+
+	var b strings.Builder
+	dash := false
+	n := 0
+	for _, r := range strings.ToLower(name) {
+		if n == maxSlugLength {
+			break
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			dash = b.Len() > 0
+			continue
+		}
+		if dash {
+			b.WriteRune('-')
+			n++
+			dash = false
+			if n == maxSlugLength {
+				break
+			}
+		}
+		b.WriteRune(r)
+		n++
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// FindRoomBySlug returns the room of the workspace with the slug.
+func (c *chattingServer) FindRoomBySlug(workspaceId int64, slug string) (*Room, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, room := range c.Rooms {
+		if room.WorkspaceId == workspaceId && room.Slug == slug {
+			return room, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.NotFound, "room #%v not found", slug)
+}
+
+// slugTaken reports whether a room of the workspace already has the slug.
+// The caller must hold the server lock.
+func (c *chattingServer) slugTaken(workspaceId int64, slug string) bool {
+	for _, room := range c.Rooms {
+		if room.WorkspaceId == workspaceId && room.Slug == slug {
+			return true
+		}
+	}
+	return false
+}
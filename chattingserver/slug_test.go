@@ -0,0 +1,39 @@
+package chattingserver
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"general", "general"},
+		{"#general", "general"},
+		{"  Team Standup  ", "team-standup"},
+		{"team -- standup!", "team-standup"},
+		{"--edge--", "edge"},
+		{"Café 2", "café-2"},
+		{"!!!", ""},
+		{"", ""},
+		{strings.Repeat("a", 70), strings.Repeat("a", 64)},
+		// the dash would be the last rune, so it is dropped
+		{strings.Repeat("a", 63) + " b", strings.Repeat("a", 63)},
+	}
+
+	for _, tt := range tests {
+		if got := Slugify(tt.name); got != tt.want {
+			t.Errorf("Slugify(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSlugifyLength(t *testing.T) {
+	name := strings.Repeat("é b ", 40)
+	if n := utf8.RuneCountInString(Slugify(name)); n > maxSlugLength {
+		t.Errorf("Slugify() is %v runes long, want at most %v", n, maxSlugLength)
+	}
+}
@@ -0,0 +1,104 @@
+package chattingserver
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultTokenTTL is how long session tokens stay valid by default. Clients
+// keep their session going with RefreshSession.
+const DefaultTokenTTL = 15 * time.Minute
+
+// TokenSigner issues and verifies session tokens. A token is
+// "<payload>.<signature>", both base64url encoded, where the payload is
+// "<userId>.<sessionId>.<expires at, unix seconds>" and the signature its
+// HMAC-SHA256.
+type TokenSigner struct {
+	key []byte
+	ttl time.Duration
+}
+
+// NewTokenSigner returns a signer using key, or a random key when key is
+// empty. Servers sharing the key accept each other's tokens.
+func NewTokenSigner(key []byte, ttl time.Duration) (*TokenSigner, error) {
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+	}
+	if ttl <= 0 {
+		ttl = DefaultTokenTTL
+	}
+
+	return &TokenSigner{key: key, ttl: ttl}, nil
+}
+
+func (t *TokenSigner) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, t.key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// Issue returns a token for the session of userId and when it expires.
+func (t *TokenSigner) Issue(userId int64, sessionId int64) (string, time.Time) {
+	expiresAt := time.Now().Add(t.ttl)
+	payload := []byte(fmt.Sprintf("%d.%d.%d", userId, sessionId, expiresAt.Unix()))
+
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(t.sign(payload)), expiresAt
+}
+
+// Verify returns the user and the session the token was issued for.
+func (t *TokenSigner) Verify(token string) (int64, int64, error) {
+	invalid := status.Error(codes.Unauthenticated, "invalid token")
+
+	encPayload, encSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, 0, invalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encPayload)
+	if err != nil {
+		return 0, 0, invalid
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encSig)
+	if err != nil {
+		return 0, 0, invalid
+	}
+	if !hmac.Equal(sig, t.sign(payload)) {
+		return 0, 0, invalid
+	}
+
+	fields := strings.Split(string(payload), ".")
+	if len(fields) != 3 {
+		return 0, 0, invalid
+	}
+	userId, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, 0, invalid
+	}
+	sessionId, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, 0, invalid
+	}
+	expires, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return 0, 0, invalid
+	}
+
+	if time.Now().After(time.Unix(expires, 0)) {
+		return 0, 0, status.Error(codes.Unauthenticated, "token expired; refresh the session or log in again")
+	}
+
+	return userId, sessionId, nil
+}
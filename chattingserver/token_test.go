@@ -0,0 +1,77 @@
+package chattingserver
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestSigner(t *testing.T, key string) *TokenSigner {
+	t.Helper()
+
+	signer, err := NewTokenSigner([]byte(key), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+// signed returns a token for payload, signed by signer.
+func signed(signer *TokenSigner, payload string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(payload)) + "." + enc.EncodeToString(signer.sign([]byte(payload)))
+}
+
+func TestTokenSignerVerify(t *testing.T) {
+	signer := newTestSigner(t, "key")
+	other := newTestSigner(t, "other key")
+
+	token, _ := signer.Issue(7, 11)
+	payload, sig, _ := strings.Cut(token, ".")
+	expires := time.Now().Add(time.Minute).Unix()
+	enc := base64.RawURLEncoding
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr string
+	}{
+		{"valid", token, ""},
+		{"tampered payload", enc.EncodeToString([]byte(fmt.Sprintf("8.11.%d", expires))) + "." + sig, "invalid token"},
+		{"tampered signature", payload + "." + enc.EncodeToString([]byte("not the signature")), "invalid token"},
+		{"other key", signed(other, fmt.Sprintf("7.11.%d", expires)), "invalid token"},
+		{"expired", signed(signer, fmt.Sprintf("7.11.%d", time.Now().Add(-time.Second).Unix())), "token expired"},
+		{"empty", "", "invalid token"},
+		{"no signature", payload, "invalid token"},
+		{"bad base64", "!!!." + sig, "invalid token"},
+		{"two fields", signed(signer, "7.11"), "invalid token"},
+		{"four fields", signed(signer, fmt.Sprintf("7.11.%d.1", expires)), "invalid token"},
+		{"bad user id", signed(signer, fmt.Sprintf("x.11.%d", expires)), "invalid token"},
+		{"bad session id", signed(signer, fmt.Sprintf("7.x.%d", expires)), "invalid token"},
+		{"bad expiry", signed(signer, "7.11.x"), "invalid token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userId, sessionId, err := signer.Verify(tt.token)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Verify() error = %v", err)
+				}
+				if userId != 7 || sessionId != 11 {
+					t.Errorf("Verify() = %v, %v, want 7, 11", userId, sessionId)
+				}
+				return
+			}
+
+			if status.Code(err) != codes.Unauthenticated || !strings.Contains(status.Convert(err).Message(), tt.wantErr) {
+				t.Errorf("Verify() error = %v, want Unauthenticated %q", err, tt.wantErr)
+			}
+		})
+	}
+}
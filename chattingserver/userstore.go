@@ -0,0 +1,102 @@
+package chattingserver
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Account is a registered user.
+type Account struct {
+	UserId   int64
+	Username string
+	// PasswordHash is the encoded argon2id hash of the password.
+	PasswordHash string
+	CreatedAt    time.Time
+	Profile      Profile
+}
+
+// UserStore keeps the registered accounts. Usernames are unique ignoring
+// case.
+type UserStore interface {
+	// Create stores the account, failing with AlreadyExists when the
+	// username is taken.
+	Create(account *Account) error
+	// FindByUsername and FindById fail with NotFound for unknown accounts.
+	FindByUsername(username string) (*Account, error)
+	FindById(userId int64) (*Account, error)
+	// UpdateProfile replaces the profile of the account and returns the
+	// updated account. Accounts returned before are left unchanged.
+	UpdateProfile(userId int64, profile Profile) (*Account, error)
+}
+
+// memoryUserStore is a UserStore that lives as long as the server.
+type memoryUserStore struct {
+	mu         sync.RWMutex
+	byId       map[int64]*Account
+	byUsername map[string]*Account
+}
+
+func NewMemoryUserStore() UserStore {
+	return &memoryUserStore{
+		byId:       map[int64]*Account{},
+		byUsername: map[string]*Account{},
+	}
+}
+
+func (m *memoryUserStore) Create(account *Account) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := strings.ToLower(account.Username)
+	if _, ok := m.byUsername[key]; ok {
+		return status.Errorf(codes.AlreadyExists, "username %v is taken", account.Username)
+	}
+
+	m.byId[account.UserId] = account
+	m.byUsername[key] = account
+	return nil
+}
+
+func (m *memoryUserStore) FindByUsername(username string) (*Account, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	account, ok := m.byUsername[strings.ToLower(username)]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "user %v not found", username)
+	}
+	return account, nil
+}
+
+func (m *memoryUserStore) FindById(userId int64) (*Account, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	account, ok := m.byId[userId]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "user %v not found", userId)
+	}
+	return account, nil
+}
+
+func (m *memoryUserStore) UpdateProfile(userId int64, profile Profile) (*Account, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	account, ok := m.byId[userId]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "user %v not found", userId)
+	}
+
+	// readers may still hold the old account, so it is replaced rather than
+	// changed
+	updated := *account
+	updated.Profile = profile
+	m.byId[userId] = &updated
+	m.byUsername[strings.ToLower(updated.Username)] = &updated
+	return &updated, nil
+}
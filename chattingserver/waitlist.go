@@ -0,0 +1,92 @@
+package chattingserver
+
+// waiter is a user queued for a slot in a full room.
+type waiter struct {
+	userId   int64
+	admitted bool
+
+	// notify is signalled when the position or admission of the waiter changes
+	notify chan struct{}
+}
+
+func (w *waiter) signal() {
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+// IsFull reports whether userId would exceed the room capacity by entering.
+// The caller must hold the room lock.
+func (r *Room) IsFull(userId int64) bool {
+	if r.MaxMembers == 0 {
+		return false
+	}
+	if _, ok := r.Users[userId]; ok {
+		return false
+	}
+	if _, ok := r.Reserved[userId]; ok {
+		return false
+	}
+
+	return len(r.Users)+len(r.Reserved) >= int(r.MaxMembers)
+}
+
+// enqueue appends userId to the waitlist. The caller must hold the room lock.
+func (r *Room) enqueue(userId int64) *waiter {
+	w := &waiter{userId: userId, notify: make(chan struct{}, 1)}
+	r.Waitlist = append(r.Waitlist, w)
+	return w
+}
+
+// position returns the 1-based waitlist position of w, or 0 once it left the
+// waitlist. The caller must hold the room lock.
+func (r *Room) position(w *waiter) int32 {
+	for i, other := range r.Waitlist {
+		if other == w {
+			return int32(i + 1)
+		}
+	}
+	return 0
+}
+
+// dequeue drops w from the waitlist, or releases the slot held for it once
+// admitted. The caller must hold the room lock.
+func (r *Room) dequeue(w *waiter) {
+	if w.admitted {
+		delete(r.Reserved, w.userId)
+		r.admitWaiters()
+		return
+	}
+
+	for i, other := range r.Waitlist {
+		if other == w {
+			r.Waitlist = append(r.Waitlist[:i], r.Waitlist[i+1:]...)
+			break
+		}
+	}
+	for _, other := range r.Waitlist {
+		other.signal()
+	}
+}
+
+// admitWaiters hands free slots to the head of the waitlist in FIFO order.
+// The caller must hold the room lock.
+func (r *Room) admitWaiters() {
+	admitted := false
+	for len(r.Waitlist) > 0 && (r.MaxMembers == 0 || len(r.Users)+len(r.Reserved) < int(r.MaxMembers)) {
+		w := r.Waitlist[0]
+		r.Waitlist = r.Waitlist[1:]
+
+		w.admitted = true
+		r.Reserved[w.userId] = struct{}{}
+		w.signal()
+		admitted = true
+	}
+
+	if admitted {
+		for _, other := range r.Waitlist {
+			other.signal()
+		}
+	}
+}
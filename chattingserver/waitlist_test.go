@@ -0,0 +1,58 @@
+package chattingserver
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWaitlistPromotion(t *testing.T) {
+	c := newTestServer(t)
+	room := newTestRoom(t, c, 1)
+	room.MaxMembers = 1
+	enter(t, c, room, 1, 10)
+
+	if _, _, err := c.EnterRoom(defaultWorkspaceId, 2, 20, room.RoomId); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("EnterRoom() of a full room error = %v, want ResourceExhausted", err)
+	}
+
+	room.mu.Lock()
+	second, third := room.enqueue(2), room.enqueue(3)
+	room.mu.Unlock()
+
+	if err := c.ExitRoom(defaultWorkspaceId, 1, 10, room.RoomId); err != nil {
+		t.Fatal(err)
+	}
+
+	room.mu.Lock()
+	admitted, position := second.admitted, room.position(third)
+	room.mu.Unlock()
+	if !admitted || position != 1 {
+		t.Fatalf("after the exit: head admitted = %v, next at %v, want true and 1", admitted, position)
+	}
+	select {
+	case <-third.notify:
+	default:
+		t.Error("the next waiter was not told its new position")
+	}
+
+	// the slot is held for the admitted waiter
+	if _, _, err := c.EnterRoom(defaultWorkspaceId, 4, 40, room.RoomId); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("EnterRoom() of a reserved slot error = %v, want ResourceExhausted", err)
+	}
+	enter(t, c, room, 2, 20)
+
+	// the slot of an admitted waiter giving up goes to the next one
+	if err := c.ExitRoom(defaultWorkspaceId, 2, 20, room.RoomId); err != nil {
+		t.Fatal(err)
+	}
+	room.mu.Lock()
+	admitted = third.admitted
+	room.dequeue(third)
+	room.mu.Unlock()
+	if !admitted {
+		t.Fatal("the next waiter was not admitted")
+	}
+	enter(t, c, room, 4, 40)
+}
@@ -0,0 +1,115 @@
+package chattingserver
+
+import (
+	pb "grpc-example/chatting"
+)
+
+// roomWatcherBuffer is the number of room events a watcher may lag behind
+// before it is dropped.
+const roomWatcherBuffer = 64
+
+type roomWatcher struct {
+	workspaceId int64
+	userId      int64
+	events      chan *pb.RoomEvent
+	// shown holds the rooms the watcher was told about and not told removed
+	shown map[int64]struct{}
+
+	// lagged is closed when the watcher fell too far behind
+	lagged chan struct{}
+	// removed is closed when the user was removed from the workspace
+	removed chan struct{}
+}
+
+// WatchRoomEvents starts watching the room events of the workspace and
+// returns the rooms visible to userId as of the start of the watch.
+func (c *chattingServer) WatchRoomEvents(workspaceId int64, userId int64) (*roomWatcher, []*pb.Room) {
+	w := &roomWatcher{
+		workspaceId: workspaceId,
+		userId:      userId,
+		events:      make(chan *pb.RoomEvent, roomWatcherBuffer),
+		shown:       map[int64]struct{}{},
+		lagged:      make(chan struct{}),
+		removed:     make(chan struct{}),
+	}
+
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+
+	// no event is broadcast between the snapshot and the watch
+	snapshot := c.VisibleRooms(workspaceId, userId)
+	for _, room := range snapshot {
+		w.shown[room.RoomId] = struct{}{}
+	}
+	c.watchers[w] = struct{}{}
+
+	return w, snapshot
+}
+
+func (c *chattingServer) UnwatchRoomEvents(w *roomWatcher) {
+	c.watchMu.Lock()
+	delete(c.watchers, w)
+	c.watchMu.Unlock()
+}
+
+// EndRoomWatchers drops the watchers of userId in the workspace.
+func (c *chattingServer) EndRoomWatchers(workspaceId int64, userId int64) {
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+
+	for w := range c.watchers {
+		if w.workspaceId == workspaceId && w.userId == userId {
+			delete(c.watchers, w)
+			close(w.removed)
+		}
+	}
+}
+
+// BroadcastRoomEvent sends an event about room to every watcher of its
+// workspace allowed to see it. Watchers that were shown the room but may no
+// longer see it are sent its removal instead. Watchers whose buffer is full
+// are dropped.
+func (c *chattingServer) BroadcastRoomEvent(eventType pb.RoomEventType, room *Room) {
+	event := &pb.RoomEvent{
+		Type:  eventType,
+		Rooms: []*pb.Room{room.ToProto()},
+	}
+	hidden := &pb.RoomEvent{
+		Type:  pb.RoomEventType_ROOM_EVENT_REMOVED,
+		Rooms: []*pb.Room{{RoomId: room.RoomId, WorkspaceId: room.WorkspaceId}},
+	}
+
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+
+	for w := range c.watchers {
+		if w.workspaceId != room.WorkspaceId {
+			continue
+		}
+
+		room.mu.Lock()
+		visible := room.CanView(w.userId)
+		room.mu.Unlock()
+
+		_, shown := w.shown[room.RoomId]
+		send := event
+		switch {
+		case visible && eventType != pb.RoomEventType_ROOM_EVENT_REMOVED:
+			w.shown[room.RoomId] = struct{}{}
+		case visible:
+			delete(w.shown, room.RoomId)
+		case shown:
+			delete(w.shown, room.RoomId)
+			send = hidden
+		default:
+			continue
+		}
+
+		select {
+		case w.events <- send:
+		default:
+			delete(c.watchers, w)
+			close(w.lagged)
+		}
+	}
+}
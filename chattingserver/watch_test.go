@@ -0,0 +1,52 @@
+package chattingserver
+
+import (
+	pb "grpc-example/chatting"
+	"testing"
+)
+
+func TestBroadcastRoomEventHidden(t *testing.T) {
+	c := newTestServer(t)
+	room := newTestRoom(t, c, 1)
+
+	owner, ownerRooms := c.WatchRoomEvents(defaultWorkspaceId, 1)
+	other, otherRooms := c.WatchRoomEvents(defaultWorkspaceId, 2)
+	if len(ownerRooms) != 1 || len(otherRooms) != 1 {
+		t.Fatalf("snapshots = %v and %v rooms, want the room in both", len(ownerRooms), len(otherRooms))
+	}
+
+	room.mu.Lock()
+	room.Visibility = pb.Visibility_VISIBILITY_PRIVATE
+	room.mu.Unlock()
+	c.BroadcastRoomEvent(pb.RoomEventType_ROOM_EVENT_UPDATED, room)
+	c.BroadcastRoomEvent(pb.RoomEventType_ROOM_EVENT_UPDATED, room)
+
+	for i := 0; i < 2; i++ {
+		if event := <-owner.events; event.Type != pb.RoomEventType_ROOM_EVENT_UPDATED {
+			t.Errorf("owner got %v, want the update", event.Type)
+		}
+	}
+
+	event := <-other.events
+	if event.Type != pb.RoomEventType_ROOM_EVENT_REMOVED || event.Rooms[0].RoomId != room.RoomId {
+		t.Errorf("other got %v, want the removal of room %v", event, room.RoomId)
+	}
+	if event.Rooms[0].RoomName != "" {
+		t.Errorf("removal shows room name %q to a user who may not see it", event.Rooms[0].RoomName)
+	}
+	select {
+	case event := <-other.events:
+		t.Errorf("other got %v after the removal", event.Type)
+	default:
+	}
+
+	// watchers are shown rooms again once they may see them
+	room.mu.Lock()
+	room.Visibility = pb.Visibility_VISIBILITY_PUBLIC
+	room.mu.Unlock()
+	c.BroadcastRoomEvent(pb.RoomEventType_ROOM_EVENT_UPDATED, room)
+
+	if event := <-other.events; event.Type != pb.RoomEventType_ROOM_EVENT_UPDATED {
+		t.Errorf("other got %v, want the update", event.Type)
+	}
+}
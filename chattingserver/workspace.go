@@ -0,0 +1,179 @@
+package chattingserver
+
+import (
+	pb "grpc-example/chatting"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultWorkspaceId is the workspace of calls that name none. Every user is
+// a member of it.
+const defaultWorkspaceId = 0
+
+type Workspace struct {
+	WorkspaceId int64
+	Name        string
+	CreatedBy   int64
+	CreatedAt   time.Time
+
+	// Members holds every user of the workspace and whether they are an
+	// admin of it.
+	Members map[int64]bool
+}
+
+func NewWorkspace(name string, createdBy int64) *Workspace {
+	return &Workspace{
+		Name:      name,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+		Members:   map[int64]bool{createdBy: true},
+	}
+}
+
+// ToProto describes the workspace as seen by userId.
+// The caller must hold the server lock.
+func (w *Workspace) ToProto(userId int64) *pb.Workspace {
+	return &pb.Workspace{
+		WorkspaceId: w.WorkspaceId,
+		Name:        w.Name,
+		CreatedBy:   w.CreatedBy,
+		CreatedAt:   timestamppb.New(w.CreatedAt),
+		MemberCount: int32(len(w.Members)),
+		Admin:       w.IsAdmin(userId),
+	}
+}
+
+// IsMember tells whether userId may use the workspace.
+// The caller must hold the server lock.
+func (w *Workspace) IsMember(userId int64) bool {
+	if w.WorkspaceId == defaultWorkspaceId {
+		return true
+	}
+
+	_, ok := w.Members[userId]
+	return ok
+}
+
+// The caller must hold the server lock.
+func (w *Workspace) IsAdmin(userId int64) bool {
+	return w.Members[userId]
+}
+
+// The caller must hold the server lock.
+func (w *Workspace) countAdmins() int {
+	n := 0
+	for _, admin := range w.Members {
+		if admin {
+			n++
+		}
+	}
+	return n
+}
+
+func workspaceNotFound(workspaceId int64) error {
+	return status.Errorf(codes.NotFound, "workspace %v not found", workspaceId)
+}
+
+func (c *chattingServer) CreateWorkspaceId(workspace *Workspace) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	workspace.WorkspaceId = c.ids.NextID()
+	c.Workspaces[workspace.WorkspaceId] = workspace
+	return workspace.WorkspaceId, nil
+}
+
+// CheckWorkspace fails unless the workspace exists and userId is a member.
+func (c *chattingServer) CheckWorkspace(workspaceId int64, userId int64) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	workspace, ok := c.Workspaces[workspaceId]
+	if !ok {
+		return workspaceNotFound(workspaceId)
+	}
+	if !workspace.IsMember(userId) {
+		return status.Errorf(codes.PermissionDenied, "not a member of workspace %v", workspaceId)
+	}
+
+	return nil
+}
+
+// MemberWorkspaces returns the workspaces userId is a member of.
+func (c *chattingServer) MemberWorkspaces(userId int64) []*pb.Workspace {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var workspaces []*pb.Workspace
+	for _, workspace := range c.Workspaces {
+		if !workspace.IsMember(userId) {
+			continue
+		}
+
+		w := workspace.ToProto(userId)
+		if workspace.WorkspaceId == defaultWorkspaceId {
+			w.MemberCount = int32(len(c.Users))
+		}
+		workspaces = append(workspaces, w)
+	}
+
+	return workspaces
+}
+
+// AddMember adds userId to the workspace on behalf of adminId.
+func (c *chattingServer) AddMember(workspaceId int64, adminId int64, userId int64, admin bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	workspace, ok := c.Workspaces[workspaceId]
+	if !ok || workspaceId == defaultWorkspaceId {
+		return workspaceNotFound(workspaceId)
+	}
+	if !workspace.IsAdmin(adminId) {
+		return status.Errorf(codes.PermissionDenied, "only admins may add members to workspace %v", workspaceId)
+	}
+	if _, err := c.users.FindById(userId); err != nil {
+		return err
+	}
+
+	workspace.Members[userId] = workspace.Members[userId] || admin
+
+	return nil
+}
+
+// RemoveMember removes userId from the workspace on behalf of adminId and
+// returns the rooms of the workspace. Members may always remove themselves.
+func (c *chattingServer) RemoveMember(workspaceId int64, adminId int64, userId int64) ([]*Room, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	workspace, ok := c.Workspaces[workspaceId]
+	if !ok || workspaceId == defaultWorkspaceId {
+		return nil, workspaceNotFound(workspaceId)
+	}
+	if adminId != userId && !workspace.IsAdmin(adminId) {
+		return nil, status.Errorf(codes.PermissionDenied, "only admins may remove members from workspace %v", workspaceId)
+	}
+
+	admin, ok := workspace.Members[userId]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "user %v is not a member of workspace %v", userId, workspaceId)
+	}
+	if admin && workspace.countAdmins() == 1 && len(workspace.Members) > 1 {
+		return nil, status.Error(codes.FailedPrecondition, "workspace must keep at least one admin")
+	}
+
+	delete(workspace.Members, userId)
+
+	var rooms []*Room
+	for _, room := range c.Rooms {
+		if room.WorkspaceId == workspaceId {
+			rooms = append(rooms, room)
+		}
+	}
+
+	return rooms, nil
+}
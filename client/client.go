@@ -3,30 +3,80 @@ package main
 import (
 	"bufio"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"flag"
 	"fmt"
 	pb "grpc-example/chatting"
+	"grpc-example/tlsconfig"
 	"io"
 	"log"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"text/tabwriter"
 	"time"
 
+	"golang.org/x/term"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 var (
 	port = flag.String("p", "08061", "Port")
+
+	useTLS  = flag.Bool("tls", false, "Connect with TLS, trusting the system CAs unless -tls-ca is set")
+	tlsCA   = flag.String("tls-ca", "", "CA file verifying the server; implies -tls")
+	tlsCert = flag.String("tls-cert", "", "Client certificate file, for mTLS; implies -tls")
+	tlsKey  = flag.String("tls-key", "", "Key file of -tls-cert")
 )
 
+var roomSorts = map[string]pb.RoomSort{
+	"name":     pb.RoomSort_ROOM_SORT_NAME,
+	"created":  pb.RoomSort_ROOM_SORT_CREATED,
+	"members":  pb.RoomSort_ROOM_SORT_MEMBER_COUNT,
+	"activity": pb.RoomSort_ROOM_SORT_ACTIVITY,
+}
+
 type chattingClient struct {
 	Cl pb.ChattingClient
 
-	UserId int32
-	RoomId int32
+	UserId int64
+	RoomId int64
+
+	// mu guards Token, ExpiresAt and RefreshToken, which the heartbeats may
+	// refresh in the background. It is held during a refresh, so that the
+	// refresh token is traded in only once.
+	mu sync.Mutex
+	// Token is the session token that authenticates every call.
+	Token string
+	// ExpiresAt is when Token expires. It is refreshed shortly before with
+	// RefreshToken.
+	ExpiresAt    time.Time
+	RefreshToken string
+	SessionId    int64
+
+	// stopHeartbeat stops the heartbeats of the session, if any are sent.
+	stopHeartbeat chan struct{}
+
+	// DeviceKey prefixes the dedup keys of the messages sent from this
+	// client, telling them from the ones sent from other devices of the user.
+	DeviceKey string
+	// WorkspaceId is the workspace all calls are made in.
+	WorkspaceId int64
+
+	// LastSeq holds the sequence number of the last message seen per room.
+	// The receiving goroutine updates it while rooms are entered, so it is
+	// guarded by seqMu.
+	LastSeq map[int64]int64
+	seqMu   sync.Mutex
 }
 
 func main() {
@@ -37,17 +87,49 @@ func main() {
 	address := "localhost:" + *port
 	fmt.Printf("Open Client to addr \"%v\"\n", address)
 
+	chattingClient := chattingClient{LastSeq: map[int64]int64{}, DeviceKey: NewDeviceKey()}
+
+	creds := insecure.NewCredentials()
+	var certUser string
+	if *useTLS || *tlsCA != "" || *tlsCert != "" {
+		config, err := tlsconfig.Client(*tlsCA, *tlsCert, *tlsKey)
+		if err != nil {
+			log.Fatalf("Fail to Load TLS Config: %v", err)
+		}
+		creds = credentials.NewTLS(config)
+
+		if len(config.Certificates) > 0 && config.Certificates[0].Leaf != nil {
+			subject := config.Certificates[0].Leaf.Subject
+			if slices.Contains(subject.OrganizationalUnit, tlsconfig.UserUnit) {
+				certUser = subject.CommonName
+			}
+		}
+	}
+
 	var opts []grpc.DialOption
-	opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	opts = append(opts, grpc.WithTransportCredentials(creds))
+	opts = append(opts, grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(FreshToken(ctx, &chattingClient, method), method, req, reply, cc, opts...)
+	}))
+	opts = append(opts, grpc.WithStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(FreshToken(ctx, &chattingClient, method), desc, cc, method, opts...)
+	}))
 	conn, err := grpc.NewClient(address, opts...)
 	if err != nil {
 		log.Fatalf("Fail to Create Client: %v", err)
 	}
 	defer conn.Close()
 
-	client := pb.NewChattingClient(conn)
+	chattingClient.Cl = pb.NewChattingClient(conn)
 
-	chattingClient := chattingClient{Cl: client}
+	// the client certificate logs the user in without a session
+	if certUser != "" {
+		user, err := GetUser(&chattingClient, &pb.GetUserRequest{Username: certUser})
+		if err == nil {
+			chattingClient.UserId = user.UserId
+			fmt.Printf("authenticated as %v (user %v) by certificate\n", user.Username, user.UserId)
+		}
+	}
 
 	for {
 		reader := bufio.NewScanner(os.Stdin)
@@ -71,219 +153,1315 @@ func main() {
 
 			cmd := strings.ToLower(firstToken[1:])
 			switch cmd {
-			case "login":
-				Login(&chattingClient)
+			case "register", "login":
+				username := ""
+				if argc > 1 {
+					username = token[1]
+				}
+				username, password, err := PromptCredentials(username, cmd == "register")
+				if err != nil {
+					continue
+				}
+				if cmd == "register" {
+					Register(&chattingClient, username, password)
+				} else {
+					Login(&chattingClient, username, password)
+				}
 			case "logout":
 				Logout(&chattingClient)
+			case "profile":
+				if argc == 1 {
+					user, err := GetUser(&chattingClient, &pb.GetUserRequest{UserId: chattingClient.UserId})
+					if err == nil {
+						PrintUser(user)
+					}
+					continue
+				}
+				value := strings.Join(token[2:], " ")
+				req := &pb.SetProfileRequest{}
+				switch token[1] {
+				case "name":
+					req.DisplayName = &value
+				case "status":
+					req.StatusText = &value
+				case "avatar":
+					req.AvatarAttachment = &value
+				default:
+					fmt.Println("usage: /profile [name|status|avatar <value>]")
+					continue
+				}
+				SetProfile(&chattingClient, req)
+			case "away", "back":
+				SetAway(&chattingClient, cmd == "away")
+			case "presence":
+				var userIds []int64
+				for _, arg := range token[1:] {
+					userId, err := strconv.ParseInt(arg, 10, 64)
+					if err != nil {
+						continue
+					}
+					userIds = append(userIds, userId)
+				}
+				if len(userIds) > 0 {
+					WatchPresence(&chattingClient, userIds)
+				}
+			case "whois":
+				if argc > 1 {
+					req := &pb.GetUserRequest{Username: token[1]}
+					if userId, err := strconv.ParseInt(token[1], 10, 64); err == nil {
+						req = &pb.GetUserRequest{UserId: userId}
+					}
+					user, err := GetUser(&chattingClient, req)
+					if err == nil {
+						PrintUser(user)
+					}
+				}
+			case "sessions":
+				if argc > 2 && token[1] == "revoke" {
+					sessionId, err := strconv.ParseInt(token[2], 10, 64)
+					if err != nil {
+						continue
+					}
+					RevokeSession(&chattingClient, sessionId)
+					continue
+				}
+				sessions, err := ListSessions(&chattingClient)
+				if err == nil {
+					PrintSessions(sessions)
+				}
 			case "create":
+				visibility := pb.Visibility_VISIBILITY_PUBLIC
+				if argc > 1 && token[1] == "-private" {
+					visibility = pb.Visibility_VISIBILITY_PRIVATE
+					token = token[1:]
+					argc--
+				}
+				if argc > 1 {
+					CreateRoom(&chattingClient, token[1], strings.Join(token[2:], " "), visibility)
+				}
+			case "workspace":
+				if argc == 1 {
+					workspaces, err := ListWorkspaces(&chattingClient)
+					if err == nil {
+						PrintWorkspaces(&chattingClient, workspaces)
+					}
+					continue
+				}
+				switch {
+				case token[1] == "new" && argc > 2:
+					workspaceId, err := CreateWorkspace(&chattingClient, strings.Join(token[2:], " "))
+					if err == nil {
+						chattingClient.WorkspaceId = workspaceId
+					}
+				case token[1] == "use" && argc > 2:
+					workspaceId, err := strconv.Atoi(token[2])
+					if err != nil {
+						continue
+					}
+					chattingClient.WorkspaceId = int64(workspaceId)
+					fmt.Printf("using workspace %v\n", workspaceId)
+				case (token[1] == "add" || token[1] == "remove") && argc > 3:
+					workspaceId, err := strconv.Atoi(token[2])
+					if err != nil {
+						continue
+					}
+					userId, err := strconv.Atoi(token[3])
+					if err != nil {
+						continue
+					}
+					admin := argc > 4 && token[4] == "-admin"
+					SetWorkspaceMember(&chattingClient, token[1], int64(workspaceId), int64(userId), admin)
+				}
+			case "invite":
 				if argc > 1 {
-					CreateRoom(&chattingClient, token[1])
+					roomId, err := ParseRoom(&chattingClient, token[1])
+					if err != nil {
+						continue
+					}
+					maxUses := 0
+					if argc > 2 {
+						maxUses, err = strconv.Atoi(token[2])
+						if err != nil {
+							continue
+						}
+					}
+					CreateInvite(&chattingClient, roomId, int32(maxUses))
+				}
+			case "join":
+				if argc > 1 {
+					JoinByInvite(&chattingClient, token[1])
 				}
 			case "remove":
 				if argc > 1 {
-					roomId, err := strconv.Atoi(token[1])
+					roomId, err := ParseRoom(&chattingClient, token[1])
 					if err != nil {
 						continue
 					}
-					DeleteRoom(&chattingClient, int32(roomId))
+					archive := argc > 2 && token[2] == "-archive"
+					DeleteRoom(&chattingClient, roomId, archive)
 				}
-			case "rooms":
-				rooms, err := GetChatRoom(&chattingClient)
+			case "grant":
+				if argc > 3 {
+					roomId, err := ParseRoom(&chattingClient, token[1])
+					if err != nil {
+						continue
+					}
+					userId, err := strconv.Atoi(token[2])
+					if err != nil {
+						continue
+					}
+					role, ok := pb.Role_value["ROLE_"+strings.ToUpper(token[3])]
+					if !ok {
+						fmt.Println("role must be one of member, moderator, owner")
+						continue
+					}
+					GrantRole(&chattingClient, roomId, int64(userId), pb.Role(role))
+				}
+			case "revoke":
+				if argc > 2 {
+					roomId, err := ParseRoom(&chattingClient, token[1])
+					if err != nil {
+						continue
+					}
+					userId, err := strconv.Atoi(token[2])
+					if err != nil {
+						continue
+					}
+					RevokeRole(&chattingClient, roomId, int64(userId))
+				}
+			case "who":
+				if argc > 1 {
+					roomId, err := ParseRoom(&chattingClient, token[1])
+					if err != nil {
+						continue
+					}
+					members, err := ListRoomMembers(&chattingClient, roomId)
+					if err != nil {
+						continue
+					}
+					PrintMembers(members)
+				}
+			case "kick", "ban", "unban", "mute":
+				if argc < 3 {
+					continue
+				}
+				roomId, err := ParseRoom(&chattingClient, token[1])
+				if err != nil {
+					continue
+				}
+				userId, err := strconv.Atoi(token[2])
 				if err != nil {
 					continue
 				}
+				arg := strings.Join(token[3:], " ")
+				Moderate(&chattingClient, cmd, roomId, int64(userId), arg)
+			case "archive", "unarchive":
+				if argc > 1 {
+					roomId, err := ParseRoom(&chattingClient, token[1])
+					if err != nil {
+						continue
+					}
+					SetArchived(&chattingClient, roomId, cmd == "archive")
+				}
+			case "slow":
+				if argc > 2 {
+					roomId, err := ParseRoom(&chattingClient, token[1])
+					if err != nil {
+						continue
+					}
+					seconds, err := strconv.Atoi(token[2])
+					if err != nil {
+						continue
+					}
+					slowMode := int32(seconds)
+					UpdateRoom(&chattingClient, &pb.UpdateRoomRequest{RoomId: roomId, SlowModeSeconds: &slowMode})
+				}
+			case "announce":
+				if argc > 2 {
+					roomId, err := ParseRoom(&chattingClient, token[1])
+					if err != nil {
+						continue
+					}
+					announcement := token[2] == "on"
+					UpdateRoom(&chattingClient, &pb.UpdateRoomRequest{RoomId: roomId, Announcement: &announcement})
+				}
+			case "search":
+				if argc > 2 {
+					roomId, err := ParseRoom(&chattingClient, token[1])
+					if err != nil {
+						continue
+					}
+					messages, err := SearchHistory(&chattingClient, roomId, strings.Join(token[2:], " "))
+					if err != nil {
+						continue
+					}
+					for _, msg := range messages {
+						fmt.Printf("|%v|#%v|[%v]|%v\n", roomId, msg.Seq, Sender(msg), msg.Msg)
+					}
+				}
+			case "session":
+				Session(&chattingClient)
+			case "watch":
+				WatchRooms(&chattingClient)
+			case "rooms":
+				// /rooms [-sort=name|created|members|activity] [-desc] [-archived] [name filter]
+				req := &pb.GetChatRoomRequest{}
+				for _, arg := range token[1:] {
+					switch {
+					case arg == "-desc":
+						req.Descending = true
+					case arg == "-archived":
+						req.IncludeArchived = true
+					case strings.HasPrefix(arg, "-sort="):
+						sortBy, ok := roomSorts[strings.TrimPrefix(arg, "-sort=")]
+						if !ok {
+							fmt.Println("sort must be one of name, created, members, activity")
+							continue
+						}
+						req.Sort = sortBy
+					default:
+						req.NameContains = arg
+					}
+				}
 
-				for _, room := range rooms {
-					fmt.Printf("| %v | %v\n", room.RoomId, room.RoomName)
+				rooms, err := GetChatRoom(&chattingClient, req)
+				if err != nil {
+					continue
 				}
+
+				PrintRooms(rooms)
 			case "enter":
-				roomId, err := strconv.Atoi(token[1])
+				if argc < 2 {
+					continue
+				}
+				roomId, err := ParseRoom(&chattingClient, token[1])
+				if err != nil {
+					continue
+				}
+
+				err = EnterChatRoom(&chattingClient, roomId)
 				if err != nil {
 					continue
 				}
 
-				err = EnterChatRoom(&chattingClient, int32(roomId))
+				Chatting(&chattingClient)
+				ExitChatRoom(&chattingClient, roomId)
+			case "wait":
+				if argc < 2 {
+					continue
+				}
+				roomId, err := ParseRoom(&chattingClient, token[1])
+				if err != nil {
+					continue
+				}
+
+				release, err := JoinWaitlist(&chattingClient, roomId)
+				if err != nil {
+					continue
+				}
+
+				err = EnterChatRoom(&chattingClient, roomId)
+				release()
 				if err != nil {
 					continue
 				}
 
 				Chatting(&chattingClient)
-				ExitChatRoom(&chattingClient, int32(roomId))
+				ExitChatRoom(&chattingClient, roomId)
 			}
 		}
 	}
 
 }
 
-func Login(client *chattingClient) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// PromptCredentials asks for the password, and for the username unless one
+// was given. With confirm set the password is asked for twice.
+func PromptCredentials(username string, confirm bool) (string, string, error) {
+	if username == "" {
+		fmt.Print("username: ")
+		reader := bufio.NewScanner(os.Stdin)
+		if !reader.Scan() {
+			return "", "", io.EOF
+		}
+		username = strings.TrimSpace(reader.Text())
+	}
 
-	user, err := client.Cl.Login(ctx, nil)
+	password, err := readPassword("password: ")
 	if err != nil {
-		log.Fatalf("client.Login failed: %v", err)
-		return err
+		return "", "", err
 	}
 
-	fmt.Printf("login to user %v\n", user.UserId)
+	if confirm {
+		again, err := readPassword("password again: ")
+		if err != nil {
+			return "", "", err
+		}
+		if again != password {
+			fmt.Println("passwords do not match")
+			return "", "", errors.New("passwords do not match")
+		}
+	}
 
-	client.UserId = user.UserId
-	return nil
+	return username, password, nil
 }
 
-func Logout(client *chattingClient) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// readPassword reads a line from stdin without echoing it when stdin is a
+// terminal.
+func readPassword(prompt string) (string, error) {
+	fmt.Print(prompt)
 
-	md := metadata.New(map[string]string{
-		"user_id": strconv.Itoa(int(client.UserId)),
-	})
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		reader := bufio.NewScanner(os.Stdin)
+		if !reader.Scan() {
+			return "", io.EOF
+		}
+		return reader.Text(), nil
+	}
 
-	ctx = metadata.NewOutgoingContext(ctx, md)
+	password, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	return string(password), err
+}
 
-	_, err := client.Cl.Logout(ctx, nil)
+func Register(client *chattingClient, username string, password string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := client.Cl.Register(ctx, &pb.RegisterRequest{Username: username, Password: password})
 	if err != nil {
-		log.Fatalf("client.Logout failed: %v", err)
+		log.Printf("client.Register failed: %v", status.Convert(err).Message())
 		return err
 	}
 
-	client.UserId = 0
+	fmt.Printf("registered and logged in as %v (user %v)\n", resp.User.Username, resp.User.UserId)
 
+	SetSession(client, resp)
+	StartHeartbeat(client)
 	return nil
 }
 
-func GetChatRoom(client *chattingClient) ([]*pb.Room, error) {
+func Login(client *chattingClient, username string, password string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	stream, err := client.Cl.GetChatRoom(ctx, &pb.Empty{})
+	resp, err := client.Cl.Login(ctx, &pb.LoginRequest{Username: username, Password: password})
 	if err != nil {
-		log.Fatalf("client.GetChatRoom failed: %v", err)
-		return nil, err
+		log.Printf("client.Login failed: %v", status.Convert(err).Message())
+		return err
 	}
 
-	var chatRoomList []*pb.Room
+	fmt.Printf("logged in as %v (user %v)\n", resp.User.Username, resp.User.UserId)
 
-	for {
-		chatRoom, err := stream.Recv()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			log.Fatalf("client.GetChatRoom failed: %v", err)
-			return nil, err
-		}
+	SetSession(client, resp)
+	StartHeartbeat(client)
+	return nil
+}
+
+// SetSession makes the session of resp the one of the client.
+func SetSession(client *chattingClient, resp *pb.LoginResponse) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	client.UserId = resp.User.UserId
+	client.SessionId = resp.SessionId
+	setTokens(client, resp)
+}
+
+// setTokens takes the tokens of resp.
+// The caller must hold the client lock.
+func setTokens(client *chattingClient, resp *pb.LoginResponse) {
+	client.Token = resp.Token
+	client.ExpiresAt = resp.ExpiresAt.AsTime()
+	client.RefreshToken = resp.RefreshToken
+}
+
+// Authorization returns the authorization metadata of the session.
+func Authorization(client *chattingClient) string {
+	client.mu.Lock()
+	defer client.mu.Unlock()
 
-		chatRoomList = append(chatRoomList, chatRoom)
+	return "Bearer " + client.Token
+}
+
+// refreshMargin is how long before it expires the session token is
+// refreshed.
+const refreshMargin = time.Minute
+
+// FreshToken refreshes the session token when it is about to expire and
+// puts the current one into the outgoing metadata of ctx.
+func FreshToken(ctx context.Context, client *chattingClient, method string) context.Context {
+	if method == pb.Chatting_RefreshSession_FullMethodName {
+		return ctx
 	}
 
-	return chatRoomList, nil
+	client.mu.Lock()
+	if client.RefreshToken != "" && time.Until(client.ExpiresAt) < refreshMargin {
+		RefreshSession(client)
+	}
+	token := client.Token
+	client.mu.Unlock()
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok || len(md.Get("authorization")) == 0 {
+		return ctx
+	}
+
+	md = md.Copy()
+	md.Set("authorization", "Bearer "+token)
+	return metadata.NewOutgoingContext(ctx, md)
 }
 
-func CreateRoom(client *chattingClient, roomName string) (int32, error) {
+// RefreshSession trades the refresh token of the client for a new session
+// token.
+// The caller must hold the client lock.
+func RefreshSession(client *chattingClient) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	room, err := client.Cl.CreateRoom(ctx, &pb.CreateRoomRequest{
-		RoomName: roomName,
-	})
+	resp, err := client.Cl.RefreshSession(ctx, &pb.RefreshSessionRequest{RefreshToken: client.RefreshToken})
 	if err != nil {
-		log.Fatalf("client.CreateRoom failed: %v", err)
-		return 0, err
+		log.Printf("client.RefreshSession failed: %v", status.Convert(err).Message())
+		if status.Code(err) == codes.Unauthenticated {
+			client.RefreshToken = ""
+		}
+		return err
 	}
 
-	return room.RoomId, nil
+	setTokens(client, resp)
+	return nil
 }
 
-func DeleteRoom(client *chattingClient, roomId int32) error {
+func ListSessions(client *chattingClient) ([]*pb.DeviceSession, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	_, err := client.Cl.RemoveRoom(ctx, &pb.RemoveRoomRequest{
-		RoomId: roomId,
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
 	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	resp, err := client.Cl.ListSessions(ctx, &pb.Empty{})
 	if err != nil {
-		log.Fatalf("client.CreateRoom failed: %v", err)
-		return err
+		log.Printf("client.ListSessions failed: %v", status.Convert(err).Message())
+		return nil, err
 	}
 
-	return nil
+	return resp.Sessions, nil
 }
 
-func EnterChatRoom(client *chattingClient, roomId int32) error {
+func PrintSessions(sessions []*pb.DeviceSession) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\tID\tDEVICE\tLOGGED IN\tEXPIRES")
+	for _, session := range sessions {
+		current := ""
+		if session.Current {
+			current = "*"
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", current, session.SessionId, session.Device,
+			session.CreatedAt.AsTime().Local().Format(time.DateTime),
+			session.ExpiresAt.AsTime().Local().Format(time.DateTime))
+	}
+	w.Flush()
+}
+
+func SetProfile(client *chattingClient, req *pb.SetProfileRequest) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	md := metadata.New(map[string]string{
-		"user_id": strconv.Itoa(int(client.UserId)),
+		"authorization": Authorization(client),
 	})
 
 	ctx = metadata.NewOutgoingContext(ctx, md)
 
-	_, err := client.Cl.EnterChatRoom(ctx, &pb.RoomRequest{RoomId: roomId})
+	user, err := client.Cl.SetProfile(ctx, req)
 	if err != nil {
-		log.Fatalf("client.EnterChatRoom failed: %v", err)
+		log.Printf("client.SetProfile failed: %v", status.Convert(err).Message())
 		return err
 	}
 
-	client.RoomId = roomId
-
+	PrintUser(user)
 	return nil
 }
 
-func ExitChatRoom(client *chattingClient, roomNumber int32) error {
+func GetUser(client *chattingClient, req *pb.GetUserRequest) (*pb.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	user, err := client.Cl.GetUser(ctx, req)
+	if err != nil {
+		log.Printf("client.GetUser failed: %v", status.Convert(err).Message())
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func PrintUser(user *pb.User) {
+	fmt.Printf("%v (@%v, user %v)\n", user.DisplayName, user.Username, user.UserId)
+	if user.StatusText != "" {
+		fmt.Printf("  %v\n", user.StatusText)
+	}
+	if user.AvatarAttachment != "" {
+		fmt.Printf("  avatar: %v\n", user.AvatarAttachment)
+	}
+}
+
+// RevokeSession logs out the session, usually of another device.
+func RevokeSession(client *chattingClient, sessionId int64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	md := metadata.New(map[string]string{
-		"user_id": strconv.Itoa(int(client.UserId)),
-		"room_id": strconv.Itoa(int(client.RoomId)),
+		"authorization": Authorization(client),
 	})
 
 	ctx = metadata.NewOutgoingContext(ctx, md)
 
-	_, err := client.Cl.ExitChatRoom(ctx, nil)
+	_, err := client.Cl.RevokeSession(ctx, &pb.RevokeSessionRequest{SessionId: sessionId})
 	if err != nil {
-		log.Fatalf("client.ExitChatRoom failed: %v", err)
+		log.Printf("client.RevokeSession failed: %v", status.Convert(err).Message())
 		return err
 	}
 
+	fmt.Printf("session %v revoked\n", sessionId)
 	return nil
 }
 
-func Chatting(client *chattingClient) {
-	ctx, cancel := context.WithCancel(context.Background())
+func Logout(client *chattingClient) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
 	md := metadata.New(map[string]string{
-		"user_id": strconv.Itoa(int(client.UserId)),
-		"room_id": strconv.Itoa(int(client.RoomId)),
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
 	})
 
 	ctx = metadata.NewOutgoingContext(ctx, md)
 
-	stream, err := client.Cl.Chatting(ctx)
+	_, err := client.Cl.Logout(ctx, nil)
 	if err != nil {
-		log.Fatalf("client.Chatting failed: %v", err)
+		log.Fatalf("client.Logout failed: %v", err)
+		return err
 	}
 
-	waitc := make(chan struct{})
+	StopHeartbeat(client)
+	client.mu.Lock()
+	client.UserId = 0
+	client.Token = ""
+	client.RefreshToken = ""
+	client.SessionId = 0
+	client.mu.Unlock()
+	client.WorkspaceId = 0
+
+	return nil
+}
+
+// StartHeartbeat keeps the user online while the client is open, replacing
+// the heartbeats of an earlier login.
+func StartHeartbeat(client *chattingClient) {
+	StopHeartbeat(client)
+
+	stop := make(chan struct{})
+	client.stopHeartbeat = stop
 
 	go func() {
+		interval := 30 * time.Second
 		for {
-			in, err := stream.Recv()
-			if err == io.EOF {
-				close(waitc)
-				return
+			if resp, err := Heartbeat(client); err == nil && resp.IntervalSeconds > 0 {
+				interval = time.Duration(resp.IntervalSeconds) * time.Second
 			}
-			if err != nil {
-				log.Fatalf("client.Chatting failed: %v", err)
+
+			select {
+			case <-stop:
+				return
+			case <-time.After(interval):
 			}
-			fmt.Printf("|%v|[%v]|%v\n", client.RoomId, client.UserId, in.Msg)
 		}
 	}()
+}
 
-	for {
-		reader := bufio.NewScanner(os.Stdin)
+func StopHeartbeat(client *chattingClient) {
+	if client.stopHeartbeat != nil {
+		close(client.stopHeartbeat)
+		client.stopHeartbeat = nil
+	}
+}
+
+func Heartbeat(client *chattingClient) (*pb.HeartbeatResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	resp, err := client.Cl.Heartbeat(ctx, &pb.Empty{})
+	if err != nil {
+		log.Printf("client.Heartbeat failed: %v", status.Convert(err).Message())
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SetAway sets the user away, or back.
+func SetAway(client *chattingClient, away bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.Cl.SetPresence(ctx, &pb.SetPresenceRequest{Away: away})
+	if err != nil {
+		log.Printf("client.SetPresence failed: %v", status.Convert(err).Message())
+		return err
+	}
+
+	if away {
+		fmt.Println("you are away")
+	} else {
+		fmt.Println("you are back")
+	}
+	return nil
+}
+
+// presenceDots show the presence of users in listings.
+var presenceDots = map[pb.Presence]string{
+	pb.Presence_PRESENCE_ONLINE:  "●",
+	pb.Presence_PRESENCE_AWAY:    "◐",
+	pb.Presence_PRESENCE_OFFLINE: "○",
+}
+
+func WatchPresence(client *chattingClient, userIds []int64) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	stream, err := client.Cl.WatchPresence(ctx, &pb.WatchPresenceRequest{UserIds: userIds})
+	if err != nil {
+		log.Printf("client.WatchPresence failed: %v", err)
+		return err
+	}
+
+	fmt.Println("watching presence, press enter to stop")
+
+	go func() {
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil {
+					fmt.Printf("watch ended: %v\n", status.Convert(err).Message())
+				}
+				return
+			}
+
+			presence := strings.ToLower(strings.TrimPrefix(event.Presence.String(), "PRESENCE_"))
+			lastSeen := ""
+			if event.Presence == pb.Presence_PRESENCE_OFFLINE && event.LastSeen != nil {
+				lastSeen = ", last seen " + event.LastSeen.AsTime().Local().Format(time.DateTime)
+			}
+			fmt.Printf("%v %v is %v%v\n", presenceDots[event.Presence], event.UserId, presence, lastSeen)
+		}
+	}()
+
+	bufio.NewScanner(os.Stdin).Scan()
+
+	return nil
+}
+
+func CreateWorkspace(client *chattingClient, name string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	workspace, err := client.Cl.CreateWorkspace(ctx, &pb.CreateWorkspaceRequest{Name: name})
+	if err != nil {
+		log.Printf("client.CreateWorkspace failed: %v", err)
+		return 0, err
+	}
+
+	fmt.Printf("created workspace %v (%v)\n", workspace.WorkspaceId, workspace.Name)
+
+	return workspace.WorkspaceId, nil
+}
+
+func ListWorkspaces(client *chattingClient) ([]*pb.Workspace, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	resp, err := client.Cl.ListWorkspaces(ctx, &pb.Empty{})
+	if err != nil {
+		log.Printf("client.ListWorkspaces failed: %v", err)
+		return nil, err
+	}
+
+	return resp.Workspaces, nil
+}
+
+func PrintWorkspaces(client *chattingClient, workspaces []*pb.Workspace) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\tID\tNAME\tMEMBERS\tADMIN")
+	for _, workspace := range workspaces {
+		current := ""
+		if workspace.WorkspaceId == client.WorkspaceId {
+			current = "*"
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", current, workspace.WorkspaceId, workspace.Name, workspace.MemberCount, workspace.Admin)
+	}
+	w.Flush()
+}
+
+// SetWorkspaceMember adds userId to or removes userId from the workspace,
+// depending on action.
+func SetWorkspaceMember(client *chattingClient, action string, workspaceId int64, userId int64, admin bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	req := &pb.WorkspaceMemberRequest{WorkspaceId: workspaceId, UserId: userId, Admin: admin}
+
+	var err error
+	if action == "add" {
+		_, err = client.Cl.AddWorkspaceMember(ctx, req)
+	} else {
+		_, err = client.Cl.RemoveWorkspaceMember(ctx, req)
+	}
+	if err != nil {
+		log.Printf("%v workspace member failed: %v", action, err)
+		return err
+	}
+
+	return nil
+}
+
+// GetChatRoom lists the rooms matching req, following pages until the last.
+func GetChatRoom(client *chattingClient, req *pb.GetChatRoomRequest) ([]*pb.Room, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	var chatRoomList []*pb.Room
+
+	for {
+		resp, err := client.Cl.GetChatRoom(ctx, req)
+		if err != nil {
+			log.Printf("client.GetChatRoom failed: %v", err)
+			return nil, err
+		}
+
+		chatRoomList = append(chatRoomList, resp.Rooms...)
+
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+
+	return chatRoomList, nil
+}
+
+func PrintRooms(rooms []*pb.Room) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintln(w, "| id\t| slug\t| name\t| topic\t| description\t| members\t| created by\t| created at")
+	for _, room := range rooms {
+		fmt.Fprintf(w, "| %v\t| #%v\t| %v\t| %v\t| %v\t| %v\t| %v\t| %v\n",
+			room.RoomId,
+			room.Slug,
+			room.RoomName,
+			room.Topic,
+			room.Description,
+			room.MemberCount,
+			room.CreatedBy,
+			room.CreatedAt.AsTime().Local().Format(time.DateTime),
+		)
+	}
+	w.Flush()
+}
+
+func PrintMembers(members []*pb.Member) {
+	for _, member := range members {
+		role := strings.ToLower(strings.TrimPrefix(member.Role.String(), "ROLE_"))
+		fmt.Printf("| %v %v | %v | %v\n", presenceDots[member.Presence], member.UserId, member.DisplayName, role)
+	}
+}
+
+func ListRoomMembers(client *chattingClient, roomId int64) ([]*pb.Member, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	resp, err := client.Cl.ListRoomMembers(ctx, &pb.RoomRequest{RoomId: roomId})
+	if err != nil {
+		log.Printf("client.ListRoomMembers failed: %v", err)
+		return nil, err
+	}
+
+	return resp.Members, nil
+}
+
+// WatchRooms prints room directory changes until a line is entered.
+func WatchRooms(client *chattingClient) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	stream, err := client.Cl.WatchRooms(ctx, &pb.Empty{})
+	if err != nil {
+		log.Printf("client.WatchRooms failed: %v", err)
+		return err
+	}
+
+	fmt.Println("watching rooms, press enter to stop")
+
+	go func() {
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil {
+					fmt.Printf("watch ended: %v\n", status.Convert(err).Message())
+				}
+				return
+			}
+
+			fmt.Printf("-- %v\n", strings.ToLower(strings.TrimPrefix(event.Type.String(), "ROOM_EVENT_")))
+			PrintRooms(event.Rooms)
+		}
+	}()
+
+	bufio.NewScanner(os.Stdin).Scan()
+
+	return nil
+}
+
+func CreateRoom(client *chattingClient, roomName string, topic string, visibility pb.Visibility) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	room, err := client.Cl.CreateRoom(ctx, &pb.CreateRoomRequest{
+		RoomName:   roomName,
+		Topic:      topic,
+		Visibility: visibility,
+	})
+	if err != nil {
+		log.Printf("client.CreateRoom failed: %v", status.Convert(err).Message())
+		return 0, err
+	}
+
+	return room.RoomId, nil
+}
+
+func DeleteRoom(client *chattingClient, roomId int64, archive bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.Cl.RemoveRoom(ctx, &pb.RemoveRoomRequest{
+		RoomId:  roomId,
+		Archive: archive,
+	})
+	if err != nil {
+		log.Printf("client.RemoveRoom failed: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// ParseRoom returns the id of the room arg names, either by id or as
+// "#slug".
+func ParseRoom(client *chattingClient, arg string) (int64, error) {
+	if !strings.HasPrefix(arg, "#") {
+		roomId, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			log.Printf("invalid room %q: want an id or #slug", arg)
+		}
+		return roomId, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	room, err := client.Cl.GetRoomByName(ctx, &pb.GetRoomByNameRequest{Name: arg})
+	if err != nil {
+		log.Printf("client.GetRoomByName failed: %v", err)
+		return 0, err
+	}
+
+	return room.RoomId, nil
+}
+
+// UpdateRoom changes the settings set in req.
+func UpdateRoom(client *chattingClient, req *pb.UpdateRoomRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.Cl.UpdateRoom(ctx, req)
+	if err != nil {
+		log.Printf("client.UpdateRoom failed: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func SetArchived(client *chattingClient, roomId int64, archived bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	var err error
+	if archived {
+		_, err = client.Cl.ArchiveRoom(ctx, &pb.RoomRequest{RoomId: roomId})
+	} else {
+		_, err = client.Cl.UnarchiveRoom(ctx, &pb.RoomRequest{RoomId: roomId})
+	}
+	if err != nil {
+		log.Printf("client.SetArchived failed: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func GrantRole(client *chattingClient, roomId int64, userId int64, role pb.Role) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.Cl.GrantRole(ctx, &pb.GrantRoleRequest{
+		RoomId: roomId,
+		UserId: userId,
+		Role:   role,
+	})
+	if err != nil {
+		log.Printf("client.GrantRole failed: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func RevokeRole(client *chattingClient, roomId int64, userId int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.Cl.RevokeRole(ctx, &pb.RevokeRoleRequest{
+		RoomId: roomId,
+		UserId: userId,
+	})
+	if err != nil {
+		log.Printf("client.RevokeRole failed: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func CreateInvite(client *chattingClient, roomId int64, maxUses int32) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	invite, err := client.Cl.CreateInvite(ctx, &pb.CreateInviteRequest{
+		RoomId:  roomId,
+		MaxUses: maxUses,
+	})
+	if err != nil {
+		log.Printf("client.CreateInvite failed: %v", err)
+		return "", err
+	}
+
+	fmt.Printf("invite code %v (%v uses, expires %v)\n",
+		invite.Code, invite.MaxUses, invite.ExpiresAt.AsTime().Local().Format(time.DateTime))
+
+	return invite.Code, nil
+}
+
+func JoinByInvite(client *chattingClient, code string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	room, err := client.Cl.JoinByInvite(ctx, &pb.JoinByInviteRequest{Code: code})
+	if err != nil {
+		log.Printf("client.JoinByInvite failed: %v", err)
+		return 0, err
+	}
+
+	fmt.Printf("joined room %v (%v)\n", room.RoomId, room.RoomName)
+
+	return room.RoomId, nil
+}
+
+// Moderate runs the kick, ban, unban or mute action on userId. arg is the
+// reason for kicks and bans, and the duration in seconds for mutes.
+func Moderate(client *chattingClient, action string, roomId int64, userId int64, arg string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	req := &pb.ModerationRequest{
+		RoomId: roomId,
+		UserId: userId,
+		Reason: arg,
+	}
+
+	var err error
+	switch action {
+	case "kick":
+		_, err = client.Cl.KickUser(ctx, req)
+	case "ban":
+		_, err = client.Cl.BanUser(ctx, req)
+	case "unban":
+		_, err = client.Cl.UnbanUser(ctx, req)
+	case "mute":
+		seconds, convErr := strconv.Atoi(arg)
+		if convErr != nil {
+			fmt.Println("usage: /mute <room> <userId> <seconds>")
+			return convErr
+		}
+		_, err = client.Cl.MuteUser(ctx, &pb.MuteUserRequest{
+			RoomId:          roomId,
+			UserId:          userId,
+			DurationSeconds: int64(seconds),
+		})
+	}
+	if err != nil {
+		log.Printf("client.%v failed: %v", action, err)
+		return err
+	}
+
+	return nil
+}
+
+func EnterChatRoom(client *chattingClient, roomId int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.Cl.EnterChatRoom(ctx, &pb.RoomRequest{RoomId: roomId})
+	if err != nil {
+		log.Printf("client.EnterChatRoom failed: %v", err)
+		return err
+	}
+
+	client.RoomId = roomId
+	ForgetSeq(client, roomId)
+
+	return nil
+}
+
+// JoinWaitlist blocks until a slot in the room is free. The slot is held until
+// release is called, which should happen once the room was entered.
+func JoinWaitlist(client *chattingClient, roomId int64) (release func(), err error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	stream, err := client.Cl.JoinWaitlist(ctx, &pb.RoomRequest{RoomId: roomId})
+	if err != nil {
+		cancel()
+		log.Printf("client.JoinWaitlist failed: %v", err)
+		return nil, err
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			cancel()
+			log.Printf("client.JoinWaitlist failed: %v", err)
+			return nil, err
+		}
+
+		if event.Admitted {
+			return cancel, nil
+		}
+
+		fmt.Printf("waiting for room %v, position %v\n", event.RoomId, event.Position)
+	}
+}
+
+func ExitChatRoom(client *chattingClient, roomNumber int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+		"room_id":       strconv.FormatInt(client.RoomId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.Cl.ExitChatRoom(ctx, nil)
+	if err != nil {
+		log.Printf("client.ExitChatRoom failed: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func Chatting(client *chattingClient) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+		"room_id":       strconv.FormatInt(client.RoomId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	stream, err := client.Cl.Chatting(ctx)
+	if err != nil {
+		log.Fatalf("client.Chatting failed: %v", err)
+	}
+
+	waitc := make(chan struct{})
+
+	go func() {
+		for {
+			in, err := stream.Recv()
+			if err == io.EOF {
+				close(waitc)
+				return
+			}
+			if err != nil {
+				if ctx.Err() == nil {
+					fmt.Printf("disconnected: %v\n", status.Convert(err).Message())
+				}
+				close(waitc)
+				return
+			}
+			Receive(client, client.RoomId, in)
+		}
+	}()
+
+	for {
+		reader := bufio.NewScanner(os.Stdin)
 		fmt.Print("Chat: ")
 
 		if reader.Scan() {
@@ -300,11 +1478,21 @@ func Chatting(client *chattingClient) {
 				break
 			}
 
+			if cmd == "/who" {
+				members, err := ListRoomMembers(client, client.RoomId)
+				if err == nil {
+					PrintMembers(members)
+				}
+				continue
+			}
+
 			msg := pb.Message{
-				Msg: input,
+				Msg:      input,
+				DedupKey: NewDedupKey(client),
 			}
 			if err := stream.Send(&msg); err != nil {
-				log.Fatalf("client.Chatting: stream.Send(%v) failed: %v", msg.Msg, err)
+				// the server ended the stream; Recv reports why
+				break
 			}
 		}
 	}
@@ -313,3 +1501,286 @@ func Chatting(client *chattingClient) {
 	stream.CloseSend()
 	<-waitc
 }
+
+// Receive prints in, first backfilling any messages of the room between the
+// last one seen and in through GetHistory. Messages already seen are dropped.
+func Receive(client *chattingClient, roomId int64, in *pb.Message) {
+	if in.Kind == pb.MessageKind_MESSAGE_KIND_NOTICE {
+		PrintMessage(client, roomId, in)
+		return
+	}
+
+	client.seqMu.Lock()
+	defer client.seqMu.Unlock()
+
+	lastSeq := client.LastSeq[roomId]
+
+	if lastSeq != 0 && in.Seq <= lastSeq {
+		return
+	}
+
+	if lastSeq != 0 && in.Seq > lastSeq+1 {
+		missed, err := GetHistory(client, roomId, lastSeq+1, in.Seq-1)
+		if status.Code(err) == codes.OutOfRange {
+			// the oldest ones are gone; show the ones the room still keeps
+			missed, err = GetHistory(client, roomId, 0, in.Seq-1)
+			lost := in.Seq - 1 - lastSeq
+			if len(missed) > 0 {
+				lost = missed[0].Seq - 1 - lastSeq
+			}
+			fmt.Printf("|%v| * %v messages are no longer available\n", roomId, lost)
+		}
+		if err != nil {
+			log.Printf("backfill %v..%v failed: %v", lastSeq+1, in.Seq-1, err)
+		}
+		for _, msg := range missed {
+			PrintMessage(client, roomId, msg)
+		}
+	}
+
+	PrintMessage(client, roomId, in)
+	client.LastSeq[roomId] = in.Seq
+}
+
+// ForgetSeq drops the last message seen in the room, so that entering it
+// again does not backfill the messages sent while the client was away.
+func ForgetSeq(client *chattingClient, roomId int64) {
+	client.seqMu.Lock()
+	delete(client.LastSeq, roomId)
+	client.seqMu.Unlock()
+}
+
+func PrintMessage(client *chattingClient, roomId int64, msg *pb.Message) {
+	if msg.Kind != pb.MessageKind_MESSAGE_KIND_CHAT {
+		fmt.Printf("|%v| * %v\n", roomId, msg.Msg)
+		return
+	}
+
+	// our own messages are echoed back by the server; the ones sent from
+	// our other devices are shown
+	if msg.UserId == client.UserId && strings.HasPrefix(msg.DedupKey, client.DeviceKey+"-") {
+		return
+	}
+
+	fmt.Printf("|%v|[%v]|%v\n", roomId, Sender(msg), msg.Msg)
+}
+
+// Sender names the sender of the message by their display name.
+func Sender(msg *pb.Message) string {
+	if msg.DisplayName != "" {
+		return msg.DisplayName
+	}
+	return strconv.FormatInt(msg.UserId, 10)
+}
+
+func GetHistory(client *chattingClient, roomId int64, fromSeq, toSeq int64) ([]*pb.Message, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	stream, err := client.Cl.GetHistory(ctx, &pb.HistoryRequest{
+		RoomId:  roomId,
+		FromSeq: fromSeq,
+		ToSeq:   toSeq,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var history []*pb.Message
+
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		history = append(history, msg)
+	}
+
+	return history, nil
+}
+
+// NewDedupKey returns a random key identifying one message, so that resending
+// it after a transient error does not duplicate it. The keys of a client start
+// with its DeviceKey.
+func NewDedupKey(client *chattingClient) string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return client.DeviceKey + "-" + hex.EncodeToString(b)
+}
+
+// NewDeviceKey returns a random key telling this client from the other
+// devices of the user.
+func NewDeviceKey() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func SearchHistory(client *chattingClient, roomId int64, query string) ([]*pb.Message, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	stream, err := client.Cl.SearchHistory(ctx, &pb.SearchHistoryRequest{
+		RoomId: roomId,
+		Query:  query,
+	})
+	if err != nil {
+		log.Printf("client.SearchHistory failed: %v", err)
+		return nil, err
+	}
+
+	var found []*pb.Message
+
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Printf("client.SearchHistory failed: %v", err)
+			return nil, err
+		}
+
+		found = append(found, msg)
+	}
+
+	return found, nil
+}
+
+// Session chats in many rooms over a single stream. Plain input goes to the
+// current room; /join, /leave and /switch manage the rooms of the session.
+func Session(client *chattingClient) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	md := metadata.New(map[string]string{
+		"authorization": Authorization(client),
+		"workspace_id":  strconv.FormatInt(client.WorkspaceId, 10),
+	})
+
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	stream, err := client.Cl.Session(ctx)
+	if err != nil {
+		cancel()
+		log.Printf("client.Session failed: %v", err)
+		return
+	}
+
+	waitc := make(chan struct{})
+
+	go func() {
+		for {
+			in, err := stream.Recv()
+			if err == io.EOF {
+				close(waitc)
+				return
+			}
+			if err != nil {
+				if ctx.Err() == nil {
+					fmt.Printf("disconnected: %v\n", status.Convert(err).Message())
+				}
+				close(waitc)
+				return
+			}
+			Receive(client, in.RoomId, in.Message)
+		}
+	}()
+
+	fmt.Println("session: /join <room>, /leave [room], /switch <room>, /who [room], /exit; rooms are ids or #slugs")
+
+	var current int64
+	joined := map[int64]bool{}
+
+	for {
+		reader := bufio.NewScanner(os.Stdin)
+		fmt.Printf("Chat %v: ", current)
+
+		if !reader.Scan() {
+			continue
+		}
+
+		input := reader.Text()
+		token := strings.Split(input, " ")
+		cmd := strings.ToLower(token[0])
+
+		roomArg := current
+		if len(token) > 1 && slices.Contains([]string{"/join", "/leave", "/switch", "/who"}, cmd) {
+			roomId, err := ParseRoom(client, token[1])
+			if err != nil {
+				continue
+			}
+			roomArg = roomId
+		}
+
+		var req *pb.SessionRequest
+		switch cmd {
+		case "/exit":
+			cancel()
+			stream.CloseSend()
+			<-waitc
+			return
+		case "/join":
+			ForgetSeq(client, roomArg)
+			joined[roomArg] = true
+			current = roomArg
+			req = &pb.SessionRequest{RoomId: roomArg, Action: pb.SessionAction_SESSION_ACTION_JOIN}
+		case "/leave":
+			delete(joined, roomArg)
+			if roomArg == current {
+				current = 0
+				for roomId := range joined {
+					current = roomId
+					break
+				}
+			}
+			req = &pb.SessionRequest{RoomId: roomArg, Action: pb.SessionAction_SESSION_ACTION_LEAVE}
+		case "/switch":
+			if !joined[roomArg] {
+				fmt.Printf("not in room %v; /join it first\n", roomArg)
+				continue
+			}
+			current = roomArg
+			continue
+		case "/who":
+			members, err := ListRoomMembers(client, roomArg)
+			if err == nil {
+				PrintMembers(members)
+			}
+			continue
+		default:
+			req = &pb.SessionRequest{
+				RoomId: current,
+				Action: pb.SessionAction_SESSION_ACTION_SEND,
+				Message: &pb.Message{
+					Msg:      input,
+					DedupKey: NewDedupKey(client),
+				},
+			}
+		}
+
+		if err := stream.Send(req); err != nil {
+			// the server ended the stream; Recv reports why
+			cancel()
+			<-waitc
+			return
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"grpc-example/tlsconfig"
+	"log"
+	"strings"
+	"time"
+)
+
+var (
+	out      = flag.String("out", "certs", "Directory to write the certificates to")
+	hosts    = flag.String("hosts", "localhost,127.0.0.1", "Comma separated host names and IPs of the server and the gateway")
+	users    = flag.String("users", "", "Comma separated usernames to issue client certificates for")
+	validFor = flag.Duration("valid-for", 365*24*time.Hour, "How long the certificates stay valid")
+)
+
+// split returns the non empty, comma separated items of list.
+func split(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func main() {
+	flag.Parse()
+
+	if err := tlsconfig.GenerateDevCerts(*out, split(*hosts), split(*users), *validFor); err != nil {
+		log.Fatalf("Fail to Generate Certificates: %v", err)
+	}
+
+	fmt.Printf("wrote development certificates to %v\n", *out)
+}
@@ -4,6 +4,8 @@ go 1.24.2
 
 require (
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.26.3
+	golang.org/x/crypto v0.35.0
+	golang.org/x/term v0.29.0
 	google.golang.org/grpc v1.72.0
 	google.golang.org/protobuf v1.36.5
 )
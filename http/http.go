@@ -4,18 +4,39 @@ import (
 	"context"
 	"flag"
 	gen "grpc-example/chatting"
+	"grpc-example/tlsconfig"
 	"net/http"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/grpclog"
 )
 
 var (
 	port = flag.String("p", "08061", "Port")
+
+	tlsCA   = flag.String("tls-ca", "", "CA file verifying the server; dials it with TLS when set")
+	tlsCert = flag.String("tls-cert", "", "Client certificate file presented to the server, for mTLS")
+	tlsKey  = flag.String("tls-key", "", "Key file of -tls-cert")
+
+	httpTLSCert = flag.String("http-tls-cert", "", "Certificate file; serves https when set")
+	httpTLSKey  = flag.String("http-tls-key", "", "Key file of -http-tls-cert")
 )
 
+// workspaceHeader selects the workspace of a request, as the
+// Grpc-Metadata-workspace_id header does.
+const workspaceHeader = "X-Workspace-Id"
+
+func headerMatcher(key string) (string, bool) {
+	if http.CanonicalHeaderKey(key) == workspaceHeader {
+		return "workspace_id", true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
 func main() {
 	flag.Parse()
 
@@ -24,12 +45,24 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	mux := runtime.NewServeMux()
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	mux := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(headerMatcher))
+	creds := insecure.NewCredentials()
+	if *tlsCA != "" || *tlsCert != "" {
+		config, err := tlsconfig.Client(*tlsCA, *tlsCert, *tlsKey)
+		if err != nil {
+			grpclog.Fatal(err)
+		}
+		creds = credentials.NewTLS(config)
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
 	err := gen.RegisterChattingHandlerFromEndpoint(ctx, mux, address, opts)
 	if err != nil {
 		grpclog.Fatal(err)
 	}
 
+	if *httpTLSCert != "" {
+		http.ListenAndServeTLS("localhost:8080", *httpTLSCert, *httpTLSKey, mux)
+		return
+	}
 	http.ListenAndServe("localhost:8080", mux)
 }
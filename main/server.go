@@ -4,34 +4,81 @@ import (
 	"flag"
 	pb "grpc-example/chatting"
 	chattingserver "grpc-example/chattingserver"
+	"grpc-example/tlsconfig"
 	"log"
 	"net"
+	"os"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 var (
 	port = flag.String("p", "08061", "Port")
+	node = flag.Int64("node", 0, "Node id, unique per server of a deployment")
+
+	tokenKey   = flag.String("token-key", "", "File with the key signing session tokens; random if empty")
+	tokenTTL   = flag.Duration("token-ttl", chattingserver.DefaultTokenTTL, "How long session tokens stay valid")
+	sessionTTL = flag.Duration("session-ttl", chattingserver.DefaultSessionTTL, "How long sessions last without being refreshed")
+
+	tlsCert     = flag.String("tls-cert", "", "Certificate file; serves TLS when set")
+	tlsKey      = flag.String("tls-key", "", "Key file of -tls-cert")
+	tlsClientCA = flag.String("tls-client-ca", "", "CA file; clients must present a certificate it signed when set")
 )
 
 func main() {
 	flag.Parse()
 
+	ids, err := chattingserver.NewSnowflake(*node)
+	if err != nil {
+		log.Fatalf("Fail to Create ID Generator: %v", err)
+	}
+
+	var key []byte
+	if *tokenKey != "" {
+		key, err = os.ReadFile(*tokenKey)
+		if err != nil {
+			log.Fatalf("Fail to Read Token Key: %v", err)
+		}
+	}
+
+	tokens, err := chattingserver.NewTokenSigner(key, *tokenTTL)
+	if err != nil {
+		log.Fatalf("Fail to Create Token Signer: %v", err)
+	}
+
+	chatting := chattingserver.NewServer(ids, chattingserver.NewMemoryUserStore(), tokens, *sessionTTL)
+
 	address := "localhost:" + *port
 	lis, err := net.Listen("tcp", address)
 	if err != nil {
 		log.Fatalf("Fail to Listen: %v", err)
 	}
 
+	var opts []grpc.ServerOption
+	if *tlsCert != "" {
+		config, err := tlsconfig.Server(*tlsCert, *tlsKey, *tlsClientCA)
+		if err != nil {
+			log.Fatalf("Fail to Load TLS Config: %v", err)
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(config)))
+	} else if *tlsClientCA != "" {
+		log.Fatalf("-tls-client-ca needs -tls-cert")
+	}
+
 	// server := grpc.NewServer(grpc.EmptyServerOption{})
-	server := grpc.NewServer(
+	server := grpc.NewServer(append(opts,
+		grpc.StatsHandler(chatting.ConnHandler()),
 		grpc.ChainUnaryInterceptor(
 			chattingserver.CustomUnaryMiddleware(),
+			chatting.IdentityUnaryMiddleware(),
 		),
 		grpc.ChainStreamInterceptor(
 			chattingserver.CustomStreamMiddleware(),
+			chatting.IdentityStreamMiddleware(),
+			chatting.PresenceStreamMiddleware(),
 		),
-	)
-	pb.RegisterChattingServer(server, chattingserver.NewServer())
+	)...)
+	pb.RegisterChattingServer(server, chatting)
 	server.Serve(lis)
 }
@@ -0,0 +1,140 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// serviceUnit is the organizational unit of the certificates of the server
+// and the gateway.
+const serviceUnit = "service"
+
+type keyPair struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// issue creates a certificate from template, signed by parent or self-signed
+// when parent is nil, and writes it to dir as name.pem and name-key.pem.
+func issue(dir string, name string, template *x509.Certificate, parent *keyPair) (*keyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	template.SerialNumber = serial
+
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, name+".pem"), certPem, 0644); err != nil {
+		return nil, err
+	}
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	if err := os.WriteFile(filepath.Join(dir, name+"-key.pem"), keyPem, 0600); err != nil {
+		return nil, err
+	}
+
+	return &keyPair{cert: cert, key: key}, nil
+}
+
+// GenerateDevCerts writes a self-signed CA to dir as ca.pem, and certificates
+// it signed for the server and the gateway, valid for hosts, and for each of
+// the users. Every certificate comes with its key as <name>-key.pem. They are
+// meant for development only.
+func GenerateDevCerts(dir string, hosts []string, users []string, validFor time.Duration) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	notBefore := time.Now().Add(-time.Hour)
+	notAfter := notBefore.Add(validFor)
+
+	ca, err := issue(dir, "ca", &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "grpc-chat dev CA"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("ca: %w", err)
+	}
+
+	var dnsNames []string
+	var ips []net.IP
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			ips = append(ips, ip)
+		} else {
+			dnsNames = append(dnsNames, host)
+		}
+	}
+
+	// the gateway serves https and dials the server, so it is both
+	services := map[string][]x509.ExtKeyUsage{
+		"server":  {x509.ExtKeyUsageServerAuth},
+		"gateway": {x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	for name, usage := range services {
+		_, err := issue(dir, name, &x509.Certificate{
+			Subject:     pkix.Name{CommonName: name, OrganizationalUnit: []string{serviceUnit}},
+			NotBefore:   notBefore,
+			NotAfter:    notAfter,
+			KeyUsage:    x509.KeyUsageDigitalSignature,
+			ExtKeyUsage: usage,
+			DNSNames:    dnsNames,
+			IPAddresses: ips,
+		}, ca)
+		if err != nil {
+			return fmt.Errorf("%v: %w", name, err)
+		}
+	}
+
+	for _, user := range users {
+		_, err := issue(dir, user, &x509.Certificate{
+			Subject:     pkix.Name{CommonName: user, OrganizationalUnit: []string{UserUnit}},
+			NotBefore:   notBefore,
+			NotAfter:    notAfter,
+			KeyUsage:    x509.KeyUsageDigitalSignature,
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}, ca)
+		if err != nil {
+			return fmt.Errorf("%v: %w", user, err)
+		}
+	}
+
+	return nil
+}